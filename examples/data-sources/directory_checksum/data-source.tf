@@ -0,0 +1,9 @@
+data "utility_directory_checksum" "config" {
+  path     = "${path.module}/config"
+  symlinks = "follow"
+}
+
+# Re-run the deployment whenever anything below config/ changes.
+resource "terraform_data" "deploy" {
+  triggers_replace = [data.utility_directory_checksum.config.sha256]
+}
@@ -0,0 +1,13 @@
+data "utility_dns_lookup" "api" {
+  hostname = "api.example.com"
+}
+
+data "utility_dns_lookup" "mail" {
+  hostname    = "example.com"
+  record_type = "MX"
+  nameserver  = "1.1.1.1:53"
+}
+
+output "api_addresses" {
+  value = data.utility_dns_lookup.api.records
+}
@@ -0,0 +1,12 @@
+data "utility_file_content" "ca" {
+  filename = "${path.module}/certs/ca.pem"
+}
+
+data "utility_file_content" "logo" {
+  filename = "${path.module}/assets/logo.png"
+  encoding = "base64"
+}
+
+output "logo_data_uri" {
+  value = "data:image/png;base64,${data.utility_file_content.logo.content}"
+}
@@ -0,0 +1,7 @@
+data "utility_file_hash" "installer" {
+  filename = "${path.module}/vendor/installer.sh"
+}
+
+output "installer_sha256" {
+  value = data.utility_file_hash.installer.sha256
+}
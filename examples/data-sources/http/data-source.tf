@@ -0,0 +1,11 @@
+data "utility_http" "config" {
+  url = "https://config.example.com/service.json"
+
+  headers = {
+    Accept = "application/json"
+  }
+}
+
+locals {
+  service = jsondecode(data.utility_http.config.response_body)
+}
@@ -0,0 +1,14 @@
+data "utility_port_check" "db" {
+  host    = "db.internal.example.com"
+  port    = 5432
+  timeout = "2s"
+}
+
+resource "terraform_data" "migrate" {
+  lifecycle {
+    precondition {
+      condition     = data.utility_port_check.db.reachable
+      error_message = "The database is not reachable."
+    }
+  }
+}
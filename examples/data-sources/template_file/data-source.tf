@@ -0,0 +1,11 @@
+data "utility_template_file" "config" {
+  template_path = "${path.module}/templates/app.yaml.tmpl"
+  vars = {
+    region   = "eu-west-1"
+    artifact = "releases/v1.4.0/app.tar.gz"
+  }
+}
+
+output "app_config" {
+  value = data.utility_template_file.config.rendered
+}
@@ -0,0 +1,9 @@
+data "utility_url_head" "release" {
+  url = "https://releases.example.com/app/1.2.3/app_linux_amd64.tar.gz"
+}
+
+resource "utility_file_downloader" "release" {
+  count    = data.utility_url_head.release.exists ? 1 : 0
+  url      = data.utility_url_head.release.id
+  filename = "${path.module}/app.tar.gz"
+}
@@ -0,0 +1,26 @@
+variable "client_secret" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+# An OAuth 2.0 client credentials grant. The access token is stored in
+# neither the plan nor the state.
+ephemeral "utility_http_token" "api" {
+  url    = "https://auth.example.com/oauth/token"
+  method = "POST"
+
+  headers = {
+    Content-Type = "application/x-www-form-urlencoded"
+  }
+
+  request_body = "grant_type=client_credentials&client_id=terraform&client_secret=${var.client_secret}"
+}
+
+provider "restapi" {
+  uri = "https://api.example.com"
+
+  headers = {
+    Authorization = "Bearer ${jsondecode(ephemeral.utility_http_token.api.value).access_token}"
+  }
+}
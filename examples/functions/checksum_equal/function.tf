@@ -0,0 +1,13 @@
+resource "utility_file_downloader" "release" {
+  url      = "https://example.com/release.tar.gz"
+  filename = "${path.module}/release.tar.gz"
+}
+
+# Published as "SHA256:9F86D081..." by the release pipeline.
+variable "published_checksum" {
+  type = string
+}
+
+output "checksum_ok" {
+  value = provider::utility::checksum_equal(var.published_checksum, utility_file_downloader.release.sha256)
+}
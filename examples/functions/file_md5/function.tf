@@ -0,0 +1,8 @@
+# The legacy artifact manifest only lists MD5 checksums.
+variable "manifest_md5" {
+  type = string
+}
+
+output "bundle_matches_manifest" {
+  value = provider::utility::checksum_equal(var.manifest_md5, provider::utility::file_md5("${path.module}/dist/bundle.zip"))
+}
@@ -0,0 +1,3 @@
+output "installer_sha1" {
+  value = provider::utility::file_sha1("${path.module}/vendor/installer.sh")
+}
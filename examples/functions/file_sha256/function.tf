@@ -0,0 +1,8 @@
+locals {
+  # Redeploy whenever the bundled configuration changes.
+  config_sha256 = provider::utility::file_sha256("${path.module}/config/app.yaml")
+}
+
+output "config_sha256" {
+  value = local.config_sha256
+}
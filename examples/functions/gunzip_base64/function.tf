@@ -0,0 +1,10 @@
+resource "utility_file_downloader" "config" {
+  url                    = "https://example.com/config.yaml"
+  filename               = "${path.module}/config.yaml"
+  output_to_state        = true
+  compress_state_content = true
+}
+
+locals {
+  config = yamldecode(provider::utility::gunzip_base64(utility_file_downloader.config.content_base64_gzip))
+}
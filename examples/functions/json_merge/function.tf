@@ -0,0 +1,15 @@
+locals {
+  defaults = jsonencode({
+    log_level = "info"
+    limits    = { cpu = "500m", memory = "512Mi" }
+  })
+}
+
+output "config" {
+  # {"limits":{"cpu":"500m","memory":"1Gi"},"replicas":3}
+  value = provider::utility::json_merge(local.defaults, jsonencode({
+    log_level = null
+    limits    = { memory = "1Gi" }
+    replicas  = 3
+  }))
+}
@@ -0,0 +1,11 @@
+output "manifest" {
+  # {"spec":{"ports":[80,443],"replicas":3}}
+  value = provider::utility::json_patch(
+    jsonencode({ spec = { replicas = 1, ports = [80] } }),
+    jsonencode([
+      { op = "test", path = "/spec/replicas", value = 1 },
+      { op = "replace", path = "/spec/replicas", value = 3 },
+      { op = "add", path = "/spec/ports/-", value = 443 },
+    ]),
+  )
+}
@@ -0,0 +1,14 @@
+data "utility_http" "release" {
+  url = "https://api.github.com/repos/hashicorp/terraform/releases/latest"
+}
+
+locals {
+  # The download URL of the first Linux amd64 asset.
+  asset_url = provider::utility::jsonpath(
+    data.utility_http.release.response_body,
+    "$.assets[?(@.name =~ /linux_amd64/)].browser_download_url",
+  )[0]
+
+  # The release notes, or an empty string if the release has none.
+  notes = provider::utility::jsonpath(data.utility_http.release.response_body, "$.body", "")
+}
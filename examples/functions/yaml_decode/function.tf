@@ -0,0 +1,7 @@
+locals {
+  chart = provider::utility::yaml_decode(file("${path.module}/Chart.yaml"))
+}
+
+output "chart_version" {
+  value = local.chart.version
+}
@@ -0,0 +1,10 @@
+resource "local_file" "values" {
+  filename = "${path.module}/values.yaml"
+  # image:
+  #   tag: "1.27"
+  # replicas: 3
+  content = provider::utility::yaml_encode({
+    replicas = 3
+    image    = { tag = "1.27" }
+  })
+}
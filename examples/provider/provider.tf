@@ -0,0 +1,3 @@
+provider "utility" {
+  max_concurrent_per_host = 4
+}
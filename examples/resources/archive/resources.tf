@@ -0,0 +1,5 @@
+resource "utility_archive" "site" {
+  source_dir  = "${path.module}/build"
+  output_path = "${path.module}/dist/site.zip"
+  exclude     = ["*.map", ".cache"]
+}
@@ -0,0 +1,12 @@
+resource "utility_command" "build" {
+  command     = ["make", "dist/app.tar.gz"]
+  working_dir = "${path.module}/app"
+  environment = {
+    VERSION = "1.2.3"
+  }
+  output_file = "dist/app.tar.gz"
+}
+
+output "build_log" {
+  value = utility_command.build.stdout
+}
@@ -0,0 +1,6 @@
+resource "utility_compress" "example" {
+  source      = "${path.module}/bundle.tar"
+  destination = "${path.module}/bundle.tar.zst"
+  format      = "zstd"
+  level       = 19
+}
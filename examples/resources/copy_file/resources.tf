@@ -0,0 +1,9 @@
+resource "utility_file_downloader" "installer" {
+  url      = "https://example.com/installer.sh"
+  filename = "${path.module}/downloads/installer.sh"
+}
+
+resource "utility_copy_file" "staged" {
+  source      = utility_file_downloader.installer.filename
+  destination = "${path.module}/stage/installer.sh"
+}
@@ -0,0 +1,11 @@
+resource "utility_directory" "cache" {
+  path = "/var/cache/app"
+  mode = "0750"
+}
+
+# Removed together with its contents when the resource is destroyed.
+resource "utility_directory" "scratch" {
+  path              = "${path.module}/.scratch"
+  delete_on_destroy = true
+  force             = true
+}
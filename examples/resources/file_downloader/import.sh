@@ -0,0 +1,3 @@
+# A file downloaded out of band can be imported by its filename. The next
+# apply fills in the attributes that cannot be derived from the file.
+terraform import utility_file_downloader.installer /opt/tools/installer.sh
@@ -1,10 +1,46 @@
-
-
 resource "utility_file_downloader" "example" {
   url      = "https://example.com/file.zip"
   filename = "${path.module}/file.zip"
 
   headers = {
+    Accept = "application/zip"
+  }
+
+  sensitive_headers = {
     Authorization = "Bearer token"
   }
 }
+
+# The token is read from the API_TOKEN environment variable when the file is
+# downloaded, so it appears in neither the configuration nor the state.
+resource "utility_file_downloader" "from_env" {
+  url      = "https://example.com/private.zip"
+  filename = "${path.module}/private.zip"
+
+  headers = {
+    Authorization = "Bearer $${env:API_TOKEN}"
+  }
+}
+
+# The same artifact is needed in two directories; it is downloaded once and
+# written to both.
+resource "utility_file_downloader" "shared" {
+  url                  = "https://example.com/agent.tar.gz"
+  filename             = "${path.module}/releases/agent.tar.gz"
+  additional_filenames = ["${path.module}/cache/agent.tar.gz"]
+}
+
+# Keep a gzip-compressed copy for archival next to the file.
+resource "utility_file_downloader" "archived" {
+  url      = "https://example.com/reports/daily.csv"
+  filename = "${path.module}/reports/daily.csv"
+  compress = "gzip"
+}
+
+# Fail instead of keeping an HTML error page served with 200 OK in place of
+# the archive.
+resource "utility_file_downloader" "checked" {
+  url                = "https://example.com/releases/tool.zip"
+  filename           = "${path.module}/tool.zip"
+  verify_magic_bytes = "zip"
+}
@@ -0,0 +1,13 @@
+locals {
+  plugins = ["auth", "metrics", "search"]
+}
+
+resource "utility_file_downloader_batch" "plugins" {
+  files = [
+    for name in local.plugins : {
+      url      = "https://example.com/plugins/${name}.jar"
+      filename = "${path.module}/plugins/${name}.jar"
+    }
+  ]
+  max_concurrency = 8
+}
@@ -0,0 +1,11 @@
+resource "utility_file_downloader" "staged" {
+  url          = "https://example.com/releases/app-1.4.0.tar.gz"
+  filename     = "${path.module}/staging/app.tar.gz"
+  refresh_mode = "never"
+}
+
+resource "utility_file_mover" "release" {
+  source             = utility_file_downloader.staged.filename
+  destination        = "/opt/app/app.tar.gz"
+  delete_destination = true
+}
@@ -0,0 +1,9 @@
+resource "utility_file_template" "nginx" {
+  template = file("${path.module}/nginx.conf.tmpl")
+  vars = {
+    server_name = "app.example.com"
+    upstream    = "127.0.0.1:8080"
+  }
+  filename  = "/etc/nginx/conf.d/app.conf"
+  file_mode = "0644"
+}
@@ -0,0 +1,6 @@
+resource "utility_http_mirror" "tools" {
+  index_url    = "https://downloads.example.com/tools/SHA256SUMS"
+  index_format = "sha256sum"
+  directory    = "${path.module}/tools"
+  concurrency  = 8
+}
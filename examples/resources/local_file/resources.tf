@@ -0,0 +1,11 @@
+resource "utility_local_file" "motd" {
+  content  = "Welcome to ${var.hostname}\n"
+  filename = "/etc/motd"
+}
+
+# Binary content is passed base64 encoded.
+resource "utility_local_file" "keystore" {
+  content_base64 = var.keystore_base64
+  filename       = "${path.module}/secrets/keystore.p12"
+  file_mode      = "0600"
+}
@@ -0,0 +1,20 @@
+resource "utility_random_password" "db" {
+  length      = 24
+  min_upper   = 2
+  min_numeric = 2
+  min_special = 1
+
+  # Generate a new password whenever the database is rebuilt.
+  keepers = {
+    database_id = var.database_id
+  }
+}
+
+resource "utility_file_downloader" "service_config" {
+  url      = "https://example.com/service.conf.tmpl"
+  filename = "${path.module}/service.conf"
+
+  template_vars = {
+    password_hash = utility_random_password.db.bcrypt_hash
+  }
+}
@@ -0,0 +1,13 @@
+# A random UUID that is replaced whenever the instance is rebuilt.
+resource "utility_random_uuid" "instance" {
+  keepers = {
+    instance_id = var.instance_id
+  }
+}
+
+# The same namespace and name always give the same UUID.
+resource "utility_random_uuid" "service" {
+  version   = 5
+  namespace = "dns"
+  name      = "api.example.com"
+}
@@ -0,0 +1,20 @@
+resource "utility_wait_for_http" "api" {
+  url     = "https://api.example.com/healthz"
+  timeout = "5m"
+}
+
+# Give the load balancer time to pick up the new backends.
+resource "utility_sleep" "settle" {
+  create_duration = "30s"
+
+  triggers = {
+    api = utility_wait_for_http.api.id
+  }
+}
+
+resource "utility_file_downloader" "schema" {
+  url      = "https://api.example.com/schema.json"
+  filename = "${path.module}/schema.json"
+
+  depends_on = [utility_sleep.settle]
+}
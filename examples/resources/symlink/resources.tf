@@ -0,0 +1,16 @@
+variable "release" {
+  type    = string
+  default = "1.4.0"
+}
+
+resource "utility_unarchive" "release" {
+  archive_path = "${path.module}/artifacts/app-${var.release}.tar.gz"
+  output_dir   = "/opt/app/releases/${var.release}"
+}
+
+resource "utility_symlink" "current" {
+  target    = "releases/${var.release}"
+  link_path = "/opt/app/current"
+
+  depends_on = [utility_unarchive.release]
+}
@@ -0,0 +1,4 @@
+resource "utility_unarchive" "site" {
+  archive_path = "${path.module}/dist/site.zip"
+  output_dir   = "${path.module}/public"
+}
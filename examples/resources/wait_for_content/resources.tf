@@ -0,0 +1,11 @@
+# Wait until every instance behind the load balancer serves the new release.
+resource "utility_wait_for_content" "rollout" {
+  url         = "https://api.example.com/version"
+  match_regex = "\"version\":\\s*\"${var.release}\""
+  interval    = "10s"
+  timeout     = "15m"
+}
+
+output "deployed_version" {
+  value = jsondecode(utility_wait_for_content.rollout.body).version
+}
@@ -0,0 +1,17 @@
+resource "utility_wait_for_http" "api" {
+  url           = "https://api.example.com/healthz"
+  body_contains = "\"status\":\"ok\""
+  interval      = "10s"
+  timeout       = "10m"
+
+  header_equals = {
+    Content-Type = "application/json"
+  }
+}
+
+resource "utility_file_downloader" "artifact" {
+  url      = "https://api.example.com/artifacts/app.zip"
+  filename = "${path.module}/app.zip"
+
+  depends_on = [utility_wait_for_http.api]
+}
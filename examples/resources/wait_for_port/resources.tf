@@ -0,0 +1,12 @@
+resource "utility_wait_for_port" "database" {
+  address  = "db.internal.example.com:5432"
+  interval = "2s"
+  timeout  = "5m"
+}
+
+resource "utility_file_downloader" "migrations" {
+  url      = "https://artifacts.example.com/migrations.tar.gz"
+  filename = "${path.module}/migrations.tar.gz"
+
+  depends_on = [utility_wait_for_port.database]
+}
@@ -0,0 +1,12 @@
+resource "utility_wait_for_url" "service" {
+  url      = "https://service.example.com/healthz"
+  interval = "10s"
+  timeout  = "10m"
+}
+
+resource "utility_file_downloader" "config" {
+  url      = "https://service.example.com/config.json"
+  filename = "${path.module}/config.json"
+
+  depends_on = [utility_wait_for_url.service]
+}
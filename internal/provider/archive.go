@@ -0,0 +1,362 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	extractOverwriteAlways  = "always"
+	extractOverwriteIfNewer = "if_newer"
+	extractOverwriteNever   = "never"
+)
+
+const (
+	archiveFormatZip   = "zip"
+	archiveFormatTar   = "tar"
+	archiveFormatTarGz = "tar.gz"
+)
+
+// archiveModTime is the modification time of every entry of a created
+// archive, so the archive only changes when the content does. It is the
+// earliest time zip can represent.
+var archiveModTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// archiveEntry is a file or directory in an archive.
+type archiveEntry struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	open    func() (io.ReadCloser, error)
+}
+
+// extractArchive extracts the zip or tar archive at archivePath into dir and
+// returns the paths of the files it wrote. The format is detected by
+// detectArchiveFormat. overwrite decides what happens to files that already
+// exist: they are always replaced, replaced if the archived file is newer, or
+// never replaced. Entries that are neither regular files nor directories are
+// skipped.
+func extractArchive(archivePath, dir, overwrite string) ([]string, error) {
+	format, err := detectArchiveFormat(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	extract := func(entry archiveEntry) error {
+		target, err := extractEntry(dir, entry, overwrite)
+		if target != "" {
+			written = append(written, target)
+		}
+		return err
+	}
+
+	switch format {
+	case archiveFormatZip:
+		err = walkZip(archivePath, extract)
+	case archiveFormatTarGz:
+		err = walkTar(archivePath, true, extract)
+	default:
+		err = walkTar(archivePath, false, extract)
+	}
+	return written, err
+}
+
+// detectArchiveFormat returns the format of the archive at archivePath. It
+// is taken from the extension (.zip, .tar, .tar.gz or .tgz) and otherwise
+// sniffed from the content, where gzip compressed data is assumed to be a
+// tar archive.
+func detectArchiveFormat(archivePath string) (string, error) {
+	name := strings.ToLower(archivePath)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return archiveFormatZip, nil
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return archiveFormatTarGz, nil
+	case strings.HasSuffix(name, ".tar"):
+		return archiveFormatTar, nil
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// The tar magic ends 262 bytes into the first header.
+	head := make([]byte, 262)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	head = head[:n]
+
+	switch {
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")), bytes.HasPrefix(head, []byte("PK\x05\x06")):
+		return archiveFormatZip, nil
+	case bytes.HasPrefix(head, []byte{0x1f, 0x8b}):
+		return archiveFormatTarGz, nil
+	case len(head) == 262 && string(head[257:262]) == "ustar":
+		return archiveFormatTar, nil
+	}
+	return "", fmt.Errorf("unsupported archive format for %s: only zip, tar and gzip compressed tar archives are supported", archivePath)
+}
+
+func walkZip(archivePath string, fn func(archiveEntry) error) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if err := fn(archiveEntry{
+			name:    f.Name,
+			mode:    f.Mode(),
+			modTime: f.Modified,
+			open: func() (io.ReadCloser, error) {
+				return f.Open()
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkTar(archivePath string, gzipped bool, fn func(archiveEntry) error) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var src io.Reader = f
+	if gzipped {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		src = gz
+	}
+
+	tr := tar.NewReader(src)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(archiveEntry{
+			name:    hdr.Name,
+			mode:    hdr.FileInfo().Mode(),
+			modTime: hdr.ModTime,
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(tr), nil
+			},
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// extractEntry writes entry below dir, refusing absolute names and names
+// that would escape it. It returns the path of the file if one was written.
+func extractEntry(dir string, entry archiveEntry, overwrite string) (string, error) {
+	if path.IsAbs(entry.name) || filepath.IsAbs(entry.name) || filepath.VolumeName(entry.name) != "" {
+		return "", fmt.Errorf("archive entry %q has an absolute path", entry.name)
+	}
+	target := filepath.Join(dir, filepath.FromSlash(entry.name))
+	if rel, err := filepath.Rel(dir, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("archive entry %q is outside of the extraction directory", entry.name)
+	}
+
+	switch {
+	case entry.mode.IsDir():
+		return "", os.MkdirAll(target, 0o755)
+	case !entry.mode.IsRegular():
+		return "", nil
+	}
+
+	if info, err := os.Lstat(target); err == nil {
+		switch overwrite {
+		case extractOverwriteNever:
+			return "", nil
+		case extractOverwriteIfNewer:
+			if !entry.modTime.After(info.ModTime()) {
+				return "", nil
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	rc, err := entry.open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	err = writeFileAtomic(target, func(w io.Writer) error {
+		_, err := io.Copy(w, rc)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("extracting %s: %w", entry.name, err)
+	}
+
+	if entry.mode.Perm()&0o111 != 0 {
+		if err := os.Chmod(target, 0o755); err != nil {
+			return target, err
+		}
+	}
+
+	// Keep the archived modification time so "if_newer" compares against
+	// the archive on the next extraction.
+	return target, os.Chtimes(target, entry.modTime, entry.modTime)
+}
+
+// archiveSourceFiles returns the slash separated paths, relative to dir, of
+// the regular files below dir, in lexical order. Files and directories whose
+// relative path matches one of the exclude patterns (in path.Match syntax)
+// are left out; an excluded directory excludes everything below it.
+// Symbolic links and other special files are skipped.
+func archiveSourceFiles(dir string, exclude []string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for _, pattern := range exclude {
+			if ok, _ := path.Match(pattern, rel); ok {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+
+		if d.Type().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// writeArchive writes files, relative to dir, to w as a zip or tar.gz
+// archive and returns the SHA256 of every file's content keyed by its
+// path. Entries keep only the executable bit of their mode and get
+// archiveModTime as modification time, so the same content always results
+// in the same archive.
+func writeArchive(w io.Writer, dir, format string, files []string) (map[string]string, error) {
+	sums := make(map[string]string, len(files))
+	add := func(name string, dst io.Writer) error {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(dst, h), f); err != nil {
+			return fmt.Errorf("archiving %s: %w", name, err)
+		}
+		sums[name] = hex.EncodeToString(h.Sum(nil))
+		return nil
+	}
+
+	switch format {
+	case archiveFormatZip:
+		zw := zip.NewWriter(w)
+		for _, name := range files {
+			info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			hdr := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: archiveModTime}
+			hdr.SetMode(archiveMode(info.Mode()))
+			entry, err := zw.CreateHeader(hdr)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(name, entry); err != nil {
+				return nil, err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	case archiveFormatTarGz:
+		gz := gzip.NewWriter(w)
+		tw := tar.NewWriter(gz)
+		for _, name := range files {
+			info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			err = tw.WriteHeader(&tar.Header{
+				Name:     name,
+				Mode:     int64(archiveMode(info.Mode())),
+				Size:     info.Size(),
+				ModTime:  archiveModTime,
+				Typeflag: tar.TypeReg,
+				Format:   tar.FormatPAX,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := add(name, tw); err != nil {
+				return nil, err
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return nil, err
+		}
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+
+	return sums, nil
+}
+
+// archiveMode reduces mode to 0755 for executables and 0644 otherwise.
+func archiveMode(mode fs.FileMode) fs.FileMode {
+	if mode.Perm()&0o111 != 0 {
+		return 0o755
+	}
+	return 0o644
+}
@@ -0,0 +1,244 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestTarGz(t *testing.T, path string, files map[string]string, modTime time.Time) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(content)),
+			ModTime:  modTime,
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+}
+
+func TestExtractArchive_Overwrite(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "bundle.tar.gz")
+	target := filepath.Join(dir, "out")
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	writeTestTarGz(t, archive, map[string]string{"conf/app.yaml": "v1"}, modTime)
+	_, err := extractArchive(archive, target, extractOverwriteAlways)
+	require.NoError(t, err)
+
+	extracted := filepath.Join(target, "conf", "app.yaml")
+	got, err := os.ReadFile(extracted)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(got))
+
+	// A local modification is kept by "never" and, being newer than the
+	// archive, by "if_newer".
+	require.NoError(t, os.WriteFile(extracted, []byte("local"), 0o644))
+	for _, policy := range []string{extractOverwriteNever, extractOverwriteIfNewer} {
+		_, err = extractArchive(archive, target, policy)
+		require.NoError(t, err)
+		got, err = os.ReadFile(extracted)
+		require.NoError(t, err)
+		assert.Equal(t, "local", string(got), policy)
+	}
+
+	writeTestTarGz(t, archive, map[string]string{"conf/app.yaml": "v2"}, time.Now().Add(time.Hour))
+	_, err = extractArchive(archive, target, extractOverwriteIfNewer)
+	require.NoError(t, err)
+	got, err = os.ReadFile(extracted)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(got))
+
+	require.NoError(t, os.WriteFile(extracted, []byte("local"), 0o644))
+	_, err = extractArchive(archive, target, extractOverwriteAlways)
+	require.NoError(t, err)
+	got, err = os.ReadFile(extracted)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(got))
+}
+
+func TestExtractArchive_Zip(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "bundle.zip")
+
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	w, err := zw.Create("bin/tool")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("#!/bin/sh\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+
+	target := filepath.Join(dir, "out")
+	_, err = extractArchive(archive, target, extractOverwriteAlways)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(target, "bin", "tool"))
+}
+
+func TestExtractArchive_Rejects(t *testing.T) {
+	dir := t.TempDir()
+
+	archive := filepath.Join(dir, "evil.tar.gz")
+	writeTestTarGz(t, archive, map[string]string{"../escape.txt": "x"}, time.Now())
+	_, err := extractArchive(archive, filepath.Join(dir, "out"), extractOverwriteAlways)
+	assert.ErrorContains(t, err, "outside of the extraction directory")
+	assert.NoFileExists(t, filepath.Join(dir, "escape.txt"))
+
+	archive = filepath.Join(dir, "absolute.tar.gz")
+	writeTestTarGz(t, archive, map[string]string{"/tmp/escape.txt": "x"}, time.Now())
+	_, err = extractArchive(archive, filepath.Join(dir, "out"), extractOverwriteAlways)
+	assert.ErrorContains(t, err, "has an absolute path")
+
+	archive = filepath.Join(dir, "file.rar")
+	require.NoError(t, os.WriteFile(archive, []byte("Rar!\x1a\x07\x00"), 0o644))
+	_, err = extractArchive(archive, dir, extractOverwriteAlways)
+	assert.ErrorContains(t, err, "unsupported archive format")
+}
+
+func TestDetectArchiveFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	tarGz := filepath.Join(dir, "bundle.tar.gz")
+	writeTestTarGz(t, tarGz, map[string]string{"a.txt": "a"}, time.Now())
+	content, err := os.ReadFile(tarGz)
+	require.NoError(t, err)
+	sniffed := filepath.Join(dir, "download")
+	require.NoError(t, os.WriteFile(sniffed, content, 0o644))
+
+	var zipped, tarred bytes.Buffer
+	files := []string{"a.txt"}
+	writeTestTree(t, filepath.Join(dir, "src"), map[string]string{"a.txt": "a"})
+	_, err = writeArchive(&zipped, filepath.Join(dir, "src"), archiveFormatZip, files)
+	require.NoError(t, err)
+	tw := tar.NewWriter(&tarred)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "a.txt", Mode: 0o644, Size: 1}))
+	_, err = tw.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zipped"), zipped.Bytes(), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tarred"), tarred.Bytes(), 0o644))
+
+	for name, want := range map[string]string{
+		"bundle.ZIP": archiveFormatZip,
+		"bundle.tgz": archiveFormatTarGz,
+		"bundle.tar": archiveFormatTar,
+		"download":   archiveFormatTarGz,
+		"zipped":     archiveFormatZip,
+		"tarred":     archiveFormatTar,
+	} {
+		got, err := detectArchiveFormat(filepath.Join(dir, name))
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	extracted, err := extractArchive(filepath.Join(dir, "tarred"), filepath.Join(dir, "out"), extractOverwriteAlways)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "out", "a.txt")}, extracted)
+}
+
+func writeTestTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+}
+
+func TestArchiveSourceFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTree(t, dir, map[string]string{
+		"main.tf":          "a",
+		"debug.tmp":        "b",
+		"cache/blob":       "c",
+		"modules/x/x.tf":   "d",
+		"modules/x/x.tmp":  "e",
+		"modules/y/README": "f",
+	})
+
+	files, err := archiveSourceFiles(dir, []string{"*.tmp", "cache", "modules/y/*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main.tf", "modules/x/x.tf", "modules/x/x.tmp"}, files)
+}
+
+func TestWriteArchive(t *testing.T) {
+	src := t.TempDir()
+	want := map[string]string{
+		"index.html":      "<h1>hello</h1>",
+		"assets/app.js":   "console.log(1)",
+		"bin/run.sh":      "#!/bin/sh\n",
+		"assets/empty.js": "",
+	}
+	writeTestTree(t, src, want)
+	require.NoError(t, os.Chmod(filepath.Join(src, "bin", "run.sh"), 0o755))
+
+	files, err := archiveSourceFiles(src, nil)
+	require.NoError(t, err)
+
+	for _, format := range []string{archiveFormatZip, archiveFormatTarGz} {
+		t.Run(format, func(t *testing.T) {
+			archivePath := filepath.Join(t.TempDir(), "bundle."+format)
+			f, err := os.Create(archivePath)
+			require.NoError(t, err)
+			sums, err := writeArchive(f, src, format, files)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			dest := t.TempDir()
+			_, err = extractArchive(archivePath, dest, extractOverwriteAlways)
+			require.NoError(t, err)
+			for name, content := range want {
+				got, err := os.ReadFile(filepath.Join(dest, filepath.FromSlash(name)))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(got), name)
+			}
+			info, err := os.Stat(filepath.Join(dest, "bin", "run.sh"))
+			require.NoError(t, err)
+			assert.NotZero(t, info.Mode().Perm()&0o100, "executable bit is kept")
+
+			extracted, err := hashTree(dest, symlinkPolicyLink)
+			require.NoError(t, err)
+			assert.Equal(t, extracted.files, sums)
+
+			// Modification times do not change the archive.
+			require.NoError(t, os.Chtimes(filepath.Join(src, "index.html"), time.Now(), time.Now()))
+			var first, second bytes.Buffer
+			_, err = writeArchive(&first, src, format, files)
+			require.NoError(t, err)
+			_, err = writeArchive(&second, src, format, files)
+			require.NoError(t, err)
+			archived, err := os.ReadFile(archivePath)
+			require.NoError(t, err)
+			assert.Equal(t, archived, first.Bytes())
+			assert.Equal(t, first.Bytes(), second.Bytes())
+		})
+	}
+}
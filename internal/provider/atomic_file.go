@@ -0,0 +1,220 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// tempFilePrefix starts the names of the temporary files written next to
+// their target, which are named .utility-tmp.<name of the target>.<digits>,
+// so files left behind by an interrupted run can be recognized and removed.
+const tempFilePrefix = ".utility-tmp."
+
+// createTempFile creates the temporary file in dir for writing path.
+func createTempFile(dir, path string) (*os.File, error) {
+	return os.CreateTemp(dir, tempFilePrefix+filepath.Base(path)+".*")
+}
+
+// removeStaleTempFiles removes the temporary files next to path that were
+// left behind by writes that never completed, and returns their paths.
+func removeStaleTempFiles(path string) ([]string, error) {
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := tempFilePrefix + filepath.Base(path) + "."
+	var removed []string
+	for _, entry := range entries {
+		// The random part of the name is all digits, which tells the
+		// temporary files of "a" from those of "a.b".
+		suffix, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || suffix == "" || strings.Trim(suffix, "0123456789") != "" || !entry.Type().IsRegular() {
+			continue
+		}
+		stale := filepath.Join(dir, entry.Name())
+		if err := os.Remove(stale); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed = append(removed, stale)
+	}
+	return removed, nil
+}
+
+// writeFileAtomic calls write with a temporary file next to path and renames
+// it over path once write succeeds, so readers never observe a partially
+// written file. The temporary file is removed if anything fails.
+func writeFileAtomic(path string, write func(w io.Writer) error) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := createTempFile(dir, path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// CreateTemp uses mode 0600; match the mode os.Create would have used.
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// fanOutFiles writes the same content to one or more paths. Each path gets
+// a temporary file next to it, and commit renames them all into place so
+// readers never observe partially written files.
+type fanOutFiles struct {
+	paths []string
+	tmps  []*os.File
+	perm  os.FileMode
+}
+
+// newFanOutFiles creates the temporary files for paths. Committed files get
+// the permissions filePerm, and missing directories are created with
+// dirPerm.
+func newFanOutFiles(paths []string, filePerm, dirPerm os.FileMode) (*fanOutFiles, error) {
+	f := &fanOutFiles{paths: paths, perm: filePerm}
+	for _, path := range paths {
+		dir := filepath.Dir(path)
+		if err := os.MkdirAll(dir, dirPerm); err != nil {
+			f.abort()
+			return nil, err
+		}
+
+		tmp, err := createTempFile(dir, path)
+		if err != nil {
+			f.abort()
+			return nil, err
+		}
+		f.tmps = append(f.tmps, tmp)
+	}
+	return f, nil
+}
+
+// Write writes p to every temporary file.
+func (f *fanOutFiles) Write(p []byte) (int, error) {
+	for _, tmp := range f.tmps {
+		if _, err := tmp.Write(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// commit moves every temporary file into place. If any of them fails, the
+// files already moved are removed again along with the remaining temporary
+// files.
+func (f *fanOutFiles) commit() error {
+	for i, tmp := range f.tmps {
+		err := tmp.Sync()
+		if err == nil {
+			err = tmp.Close()
+		}
+		if err == nil {
+			err = os.Chmod(tmp.Name(), f.perm)
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), f.paths[i])
+		}
+		if err != nil {
+			for _, done := range f.paths[:i] {
+				os.Remove(done)
+			}
+			f.tmps = f.tmps[i:]
+			f.abort()
+			return err
+		}
+	}
+
+	f.tmps = nil
+	return nil
+}
+
+// name returns the temporary file of the first path, which holds the content
+// written so far.
+func (f *fanOutFiles) name() string {
+	return f.tmps[0].Name()
+}
+
+// abort removes the temporary files that have not been committed.
+func (f *fanOutFiles) abort() {
+	for _, tmp := range f.tmps {
+		tmp.Close()
+		os.Remove(tmp.Name())
+	}
+	f.tmps = nil
+}
+
+// writeFileContent atomically writes content to path with the permissions
+// perm, creating missing directories.
+func writeFileContent(path string, content []byte, perm os.FileMode) error {
+	files, err := newFanOutFiles([]string{path}, perm, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := files.Write(content); err != nil {
+		files.abort()
+		return err
+	}
+	return files.commit()
+}
+
+// quarantineFile moves path into dir under the base name of name prefixed
+// with now, so repeated failures of the same file are all kept. It returns
+// the new path.
+func quarantineFile(path, name, dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(dir, now.UTC().Format("20060102T150405Z")+"-"+filepath.Base(name))
+	if err := os.Rename(path, dest); err == nil {
+		return dest, nil
+	}
+
+	// Renaming fails across file systems, so fall back to copying.
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	err = writeFileAtomic(dest, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return dest, os.Remove(path)
+}
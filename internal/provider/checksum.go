@@ -0,0 +1,273 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zeebo/blake3"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	checksumSHA1    = "sha1"
+	checksumSHA256  = "sha256"
+	checksumBlake2b = "blake2b"
+	checksumBlake3  = "blake3"
+	checksumCRC32   = "crc32"
+	checksumCRC64   = "crc64"
+	checksumMD5     = "md5"
+	checksumSHA512  = "sha512"
+)
+
+// extraChecksumAlgorithms lists the checksums that are only computed on
+// request, on top of SHA1 and SHA256 which are always computed.
+var extraChecksumAlgorithms = []string{checksumBlake2b, checksumBlake3, checksumCRC32, checksumCRC64, checksumMD5, checksumSHA512}
+
+var crc64Table = crc64.MakeTable(crc64.ECMA)
+
+// newExtraHash returns a hash for one of extraChecksumAlgorithms.
+func newExtraHash(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case checksumBlake2b:
+		return blake2b.New512(nil)
+	case checksumBlake3:
+		return blake3.New(), nil
+	case checksumCRC32:
+		return crc32.NewIEEE(), nil
+	case checksumCRC64:
+		return crc64.New(crc64Table), nil
+	case checksumMD5:
+		return md5.New(), nil
+	case checksumSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+type fileChecksums struct {
+	sha1Hex   string
+	sha256Hex string
+
+	// extra holds the hex encoded checksums of the requested
+	// extraChecksumAlgorithms, keyed by algorithm.
+	extra map[string]string
+}
+
+// get returns the hex encoded checksum for algorithm, or "" if it was not
+// computed.
+func (c *fileChecksums) get(algorithm string) string {
+	switch algorithm {
+	case checksumSHA1:
+		return c.sha1Hex
+	case checksumSHA256:
+		return c.sha256Hex
+	default:
+		return c.extra[algorithm]
+	}
+}
+
+// sha256HexLength is the length of a hex encoded SHA256 checksum.
+const sha256HexLength = 64
+
+var sha256HexRegexp = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+var sha1HexRegexp = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// isHexChecksum reports whether s is a hex encoded checksum of the given
+// length.
+func isHexChecksum(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// fileHasher computes SHA1, SHA256 and any requested extra checksums in one
+// pass.
+type fileHasher struct {
+	sha1   hash.Hash
+	sha256 hash.Hash
+	extra  map[string]hash.Hash
+}
+
+// newFileHasher returns a hasher that additionally computes the given
+// extraChecksumAlgorithms. Unknown algorithms, as well as sha1 and sha256,
+// are ignored.
+func newFileHasher(algorithms ...string) *fileHasher {
+	h := &fileHasher{
+		sha1:   sha1.New(),
+		sha256: sha256.New(),
+		extra:  make(map[string]hash.Hash, len(algorithms)),
+	}
+	for _, algorithm := range algorithms {
+		if extra, err := newExtraHash(algorithm); err == nil {
+			h.extra[algorithm] = extra
+		}
+	}
+	return h
+}
+
+func (h *fileHasher) Write(p []byte) (int, error) {
+	h.sha1.Write(p)
+	h.sha256.Write(p)
+	for _, extra := range h.extra {
+		extra.Write(p)
+	}
+	return len(p), nil
+}
+
+// writeConcurrent is like Write but updates each hash in its own goroutine.
+func (h *fileHasher) writeConcurrent(p []byte) {
+	var wg sync.WaitGroup
+	for _, other := range append([]hash.Hash{h.sha1}, slices.Collect(maps.Values(h.extra))...) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			other.Write(p)
+		}()
+	}
+	h.sha256.Write(p)
+	wg.Wait()
+}
+
+func (h *fileHasher) checksums() *fileChecksums {
+	checksums := &fileChecksums{
+		sha1Hex:   hex.EncodeToString(h.sha1.Sum(nil)),
+		sha256Hex: hex.EncodeToString(h.sha256.Sum(nil)),
+		extra:     make(map[string]string, len(h.extra)),
+	}
+	for algorithm, extra := range h.extra {
+		checksums.extra[algorithm] = hex.EncodeToString(extra.Sum(nil))
+	}
+	return checksums
+}
+
+// hashPipelineDepth is the number of chunks that may be in flight between
+// the writer and the hasher.
+const hashPipelineDepth = 4
+
+// copyAndHash copies src to dst while computing the checksums of the copied
+// data. When chunkSize is positive and the source is not known to be smaller
+// than a single chunk, hashing runs in its own goroutines so it overlaps with
+// the disk writes. size is the expected length of src, or -1 if unknown.
+// algorithms selects extra checksums as in newFileHasher.
+func copyAndHash(dst io.Writer, src io.Reader, size int64, chunkSize int, algorithms ...string) (int64, *fileChecksums, error) {
+	h := newFileHasher(algorithms...)
+
+	if chunkSize <= 0 || (size >= 0 && size < int64(chunkSize)) {
+		n, err := io.Copy(io.MultiWriter(dst, h), src)
+		return n, h.checksums(), err
+	}
+
+	free := make(chan []byte, hashPipelineDepth)
+	for range hashPipelineDepth {
+		free <- make([]byte, chunkSize)
+	}
+
+	chunks := make(chan []byte, hashPipelineDepth)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for chunk := range chunks {
+			h.writeConcurrent(chunk)
+			free <- chunk[:cap(chunk)]
+		}
+	}()
+
+	var written int64
+	var err error
+	for {
+		buf := <-free
+		nr, rerr := io.ReadFull(src, buf)
+		if nr > 0 {
+			nw, werr := dst.Write(buf[:nr])
+			written += int64(nw)
+			if werr != nil {
+				err = werr
+				break
+			}
+			chunks <- buf[:nr]
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			err = rerr
+			break
+		}
+	}
+
+	close(chunks)
+	<-done
+
+	return written, h.checksums(), err
+}
+
+// parseSHA256File returns the checksum listed in the content of a SHA256
+// checksum file. Each line holds either just the hex encoded checksum or, as
+// written by GNU coreutils' sha256sum, the checksum followed by whitespace
+// and the file name, which may be prefixed with "*" for binary mode. A file
+// with a single entry is used whatever its name; otherwise the entry whose
+// base name is one of names is.
+func parseSHA256File(content []byte, names ...string) (string, error) {
+	type entry struct{ sum, name string }
+	var entries []entry
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sum, name, _ := strings.Cut(strings.ReplaceAll(line, "\t", " "), " ")
+		if !isHexChecksum(sum, sha256HexLength) {
+			return "", fmt.Errorf("line %d of the checksum file does not start with a SHA256 checksum", i+1)
+		}
+		entries = append(entries, entry{sum: sum, name: strings.TrimPrefix(strings.TrimSpace(name), "*")})
+	}
+
+	switch len(entries) {
+	case 0:
+		return "", errors.New("the checksum file lists no checksum")
+	case 1:
+		return entries[0].sum, nil
+	}
+	for _, e := range entries {
+		if slices.Contains(names, path.Base(e.name)) {
+			return e.sum, nil
+		}
+	}
+	return "", fmt.Errorf("the checksum file lists no checksum for %s", strings.Join(names, " or "))
+}
+
+// hashFile returns the checksums of the file at path. algorithms selects
+// extra checksums as in newFileHasher.
+func hashFile(path string, algorithms ...string) (*fileChecksums, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	_, checksums, err := copyAndHash(io.Discard, f, -1, 0, algorithms...)
+	return checksums, err
+}
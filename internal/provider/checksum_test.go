@@ -0,0 +1,152 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"hash/crc64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/blake3"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestCopyAndHash(t *testing.T) {
+	data := []byte(testRandString(100_000))
+	sha1Sum := sha1.Sum(data)
+	sha256Sum := sha256.Sum256(data)
+
+	for _, chunkSize := range []int{0, 4096, 100_000, 1 << 20} {
+		t.Run(fmt.Sprintf("chunk_%d", chunkSize), func(t *testing.T) {
+			var out bytes.Buffer
+			n, checksums, err := copyAndHash(&out, bytes.NewReader(data), -1, chunkSize)
+			require.NoError(t, err)
+
+			assert.Equal(t, int64(len(data)), n)
+			assert.Equal(t, data, out.Bytes())
+			assert.Equal(t, hex.EncodeToString(sha1Sum[:]), checksums.sha1Hex)
+			assert.Equal(t, hex.EncodeToString(sha256Sum[:]), checksums.sha256Hex)
+		})
+	}
+}
+
+func BenchmarkCopyAndHash(b *testing.B) {
+	data := make([]byte, 64<<20)
+
+	for _, chunkSize := range []int{0, 256 << 10, 1 << 20} {
+		b.Run(fmt.Sprintf("chunk_%d", chunkSize), func(b *testing.B) {
+			out, err := os.Create(filepath.Join(b.TempDir(), "bench"))
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer out.Close()
+
+			b.SetBytes(int64(len(data)))
+			for range b.N {
+				if _, err := out.Seek(0, io.SeekStart); err != nil {
+					b.Fatal(err)
+				}
+				if _, _, err := copyAndHash(out, bytes.NewReader(data), int64(len(data)), chunkSize); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestCopyAndHash_ExtraChecksums(t *testing.T) {
+	data := []byte(testRandString(100_000))
+	blake2bSum := blake2b.Sum512(data)
+	blake3Sum := blake3.Sum256(data)
+	crc64Hash := crc64.New(crc64Table)
+	crc64Hash.Write(data)
+	md5Sum := md5.Sum(data)
+	sha512Sum := sha512.Sum512(data)
+
+	want := map[string]string{
+		checksumBlake2b: hex.EncodeToString(blake2bSum[:]),
+		checksumBlake3:  hex.EncodeToString(blake3Sum[:]),
+		checksumCRC32:   fmt.Sprintf("%08x", crc32.ChecksumIEEE(data)),
+		checksumCRC64:   hex.EncodeToString(crc64Hash.Sum(nil)),
+		checksumMD5:     hex.EncodeToString(md5Sum[:]),
+		checksumSHA512:  hex.EncodeToString(sha512Sum[:]),
+	}
+
+	for _, chunkSize := range []int{0, 4096} {
+		t.Run(fmt.Sprintf("chunk_%d", chunkSize), func(t *testing.T) {
+			_, checksums, err := copyAndHash(io.Discard, bytes.NewReader(data), -1, chunkSize, extraChecksumAlgorithms...)
+			require.NoError(t, err)
+
+			assert.Equal(t, want, checksums.extra)
+			for algorithm, sum := range want {
+				assert.Equal(t, sum, checksums.get(algorithm))
+			}
+		})
+	}
+
+	_, checksums, err := copyAndHash(io.Discard, bytes.NewReader(data), -1, 0, checksumCRC32)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{checksumCRC32: want[checksumCRC32]}, checksums.extra)
+}
+
+func TestHashFile(t *testing.T) {
+	data := []byte(testRandString(1000))
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	sha256Sum := sha256.Sum256(data)
+	md5Sum := md5.Sum(data)
+
+	// Only the requested extra checksums are computed.
+	checksums, err := hashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sha256Sum[:]), checksums.sha256Hex)
+	assert.Empty(t, checksums.extra)
+
+	checksums, err = hashFile(path, checksumMD5)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{checksumMD5: hex.EncodeToString(md5Sum[:])}, checksums.extra)
+}
+
+func TestParseSHA256File(t *testing.T) {
+	a := strings.Repeat("a", 64)
+	b := strings.Repeat("B", 64)
+
+	for name, tc := range map[string]struct {
+		content string
+		want    string
+		wantErr string
+	}{
+		"bare checksum":      {content: a + "\n", want: a},
+		"coreutils single":   {content: a + "  app.tar.gz\n", want: a},
+		"coreutils binary":   {content: a + " *other.tar.gz\n", want: a},
+		"coreutils multiple": {content: b + "  other.tar.gz\n" + a + "  dist/app.tar.gz\n", want: a},
+		"comments":           {content: "# release 1.0\n\n" + a + "\tapp.tar.gz\n", want: a},
+		"not listed":         {content: a + "  one.tar.gz\n" + b + "  two.tar.gz\n", wantErr: "no checksum for app.tar.gz"},
+		"empty":              {content: "\n", wantErr: "lists no checksum"},
+		"not a checksum":     {content: "<html>Not Found</html>", wantErr: "line 1 of the checksum file"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseSHA256File([]byte(tc.content), "app.tar.gz")
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
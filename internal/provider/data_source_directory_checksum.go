@@ -0,0 +1,103 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*directoryChecksumDataSource)(nil)
+
+type directoryChecksumDataSource struct{}
+
+func NewDirectoryChecksumDataSource() datasource.DataSource {
+	return &directoryChecksumDataSource{}
+}
+
+func (d *directoryChecksumDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_directory_checksum"
+}
+
+func (d *directoryChecksumDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that computes a deterministic checksum over every file below a directory, for use as a single trigger that changes whenever anything in the tree changes. Files are hashed one at a time while streaming, so large trees are not held in memory.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Directory to hash.",
+				Required:    true,
+			},
+			"symlinks": schema.StringAttribute{
+				Description: "How symbolic links are treated: 'link' hashes the path the link points to, 'follow' hashes the content of the file it points to, and 'skip' leaves links out. Links to directories are never descended into. Defaults to 'link'.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(symlinkPolicyLink, symlinkPolicyFollow, symlinkPolicySkip),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				Description: "Aggregate SHA256 checksum over the sorted relative paths and the checksums of all files. It changes when a file is added, removed, renamed or modified, but not when only modification times or empty directories change.",
+				Computed:    true,
+			},
+			"files": schema.MapAttribute{
+				Description: "SHA256 checksum of every file, keyed by its path relative to `path` with forward slashes.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"file_count": schema.Int64Attribute{
+				Description: "Number of files included in the checksum.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The aggregate SHA256 checksum.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type directoryChecksumDataSourceModel struct {
+	Path      types.String `tfsdk:"path"`
+	Symlinks  types.String `tfsdk:"symlinks"`
+	Sha256    types.String `tfsdk:"sha256"`
+	Files     types.Map    `tfsdk:"files"`
+	FileCount types.Int64  `tfsdk:"file_count"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func (d *directoryChecksumDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config directoryChecksumDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	symlinks := config.Symlinks.ValueString()
+	if symlinks == "" {
+		symlinks = symlinkPolicyLink
+	}
+
+	sum, err := hashTree(config.Path.ValueString(), symlinks)
+	if err != nil {
+		resp.Diagnostics.AddError("Hashing Directory Failed", err.Error())
+		return
+	}
+
+	files, diags := types.MapValueFrom(ctx, types.StringType, sum.files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.Sha256 = types.StringValue(sum.sha256Hex)
+	config.Files = files
+	config.FileCount = types.Int64Value(int64(len(sum.files)))
+	config.ID = config.Sha256
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryChecksumDataSource(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "conf"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "conf", "app.yaml"), []byte("v1"), 0o644))
+
+	want, err := hashTree(root, symlinkPolicyLink)
+	require.NoError(t, err)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_directory_checksum" "tree" {
+						path = %q
+					}`, root),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_directory_checksum.tree", "sha256", want.sha256Hex),
+					resource.TestCheckResourceAttr("data.utility_directory_checksum.tree", "id", want.sha256Hex),
+					resource.TestCheckResourceAttr("data.utility_directory_checksum.tree", "file_count", "1"),
+					resource.TestCheckResourceAttr("data.utility_directory_checksum.tree", "files.conf/app.yaml", want.files["conf/app.yaml"]),
+				),
+			},
+		},
+	})
+}
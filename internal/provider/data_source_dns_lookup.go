@@ -0,0 +1,188 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	dnsRecordA     = "A"
+	dnsRecordAAAA  = "AAAA"
+	dnsRecordCNAME = "CNAME"
+	dnsRecordTXT   = "TXT"
+	dnsRecordMX    = "MX"
+)
+
+var _ datasource.DataSource = (*dnsLookupDataSource)(nil)
+
+type dnsLookupDataSource struct{}
+
+func NewDNSLookupDataSource() datasource.DataSource {
+	return &dnsLookupDataSource{}
+}
+
+func (d *dnsLookupDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_dns_lookup"
+}
+
+func (d *dnsLookupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that resolves a hostname, for example to check during plan that a record exists before resources depend on it. Reading fails if the name cannot be resolved.",
+		Attributes: map[string]schema.Attribute{
+			"hostname": schema.StringAttribute{
+				Description: "The name to look up.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"record_type": schema.StringAttribute{
+				Description: "Type of the records to look up: 'A', 'AAAA', 'CNAME', 'TXT' or 'MX' (default: A).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsRecordA, dnsRecordAAAA, dnsRecordCNAME, dnsRecordTXT, dnsRecordMX),
+				},
+			},
+			"nameserver": schema.StringAttribute{
+				Description: "DNS server to query, as `host:port`. Defaults to the resolver configured on the system.",
+				Optional:    true,
+				Validators: []validator.String{
+					hostPortValidator{},
+				},
+			},
+			"records": schema.ListAttribute{
+				Description: "The records found, sorted. Addresses for A and AAAA, the canonical name for CNAME, the text for TXT, and the preference and host separated by a space, such as \"10 mx.example.com.\", for MX.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description: "The hostname.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type dnsLookupDataSourceModel struct {
+	Hostname   types.String `tfsdk:"hostname"`
+	RecordType types.String `tfsdk:"record_type"`
+	Nameserver types.String `tfsdk:"nameserver"`
+	Records    types.List   `tfsdk:"records"`
+	ID         types.String `tfsdk:"id"`
+}
+
+func (d *dnsLookupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dnsLookupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordType := dnsRecordA
+	if !config.RecordType.IsNull() {
+		recordType = config.RecordType.ValueString()
+	}
+
+	resolver := net.DefaultResolver
+	if nameserver := config.Nameserver.ValueString(); nameserver != "" {
+		resolver = nameserverResolver(nameserver)
+	}
+
+	hostname := config.Hostname.ValueString()
+	records, err := lookupDNS(ctx, resolver, hostname, recordType)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("hostname"),
+			"DNS Lookup Failed",
+			fmt.Sprintf("Looking up the %s records of %s: %s", recordType, hostname, err),
+		)
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, records)
+	resp.Diagnostics.Append(diags...)
+	config.Records = list
+	config.ID = types.StringValue(hostname)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
+
+// nameserverResolver returns a resolver that sends its queries to the DNS
+// server at address instead of the system's.
+func nameserverResolver(address string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// lookupDNS returns the records of type recordType for hostname, sorted so
+// that their order does not change between reads.
+func lookupDNS(ctx context.Context, resolver *net.Resolver, hostname, recordType string) ([]string, error) {
+	switch recordType {
+	case dnsRecordA, dnsRecordAAAA:
+		network := "ip4"
+		if recordType == dnsRecordAAAA {
+			network = "ip6"
+		}
+		ips, err := resolver.LookupIP(ctx, network, hostname)
+		if err != nil {
+			return nil, err
+		}
+		records := make([]string, len(ips))
+		for i, ip := range ips {
+			records[i] = ip.String()
+		}
+		slices.Sort(records)
+		return slices.Compact(records), nil
+
+	case dnsRecordCNAME:
+		cname, err := resolver.LookupCNAME(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		return []string{cname}, nil
+
+	case dnsRecordTXT:
+		records, err := resolver.LookupTXT(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(records)
+		return records, nil
+
+	case dnsRecordMX:
+		mxs, err := resolver.LookupMX(ctx, hostname)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(mxs, func(a, b *net.MX) int {
+			return cmp.Or(cmp.Compare(a.Pref, b.Pref), cmp.Compare(a.Host, b.Host))
+		})
+		records := make([]string, len(mxs))
+		for i, mx := range mxs {
+			records[i] = strconv.Itoa(int(mx.Pref)) + " " + mx.Host
+		}
+		return records, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported record type %q", recordType)
+	}
+}
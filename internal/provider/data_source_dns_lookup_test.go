@@ -0,0 +1,201 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// stubDNSZone maps a fully qualified lowercase name to its records.
+type stubDNSZone map[string][]dnsmessage.ResourceBody
+
+var testDNSZone = stubDNSZone{
+	"app.example.test.": {
+		&dnsmessage.AResource{A: [4]byte{192, 0, 2, 20}},
+		&dnsmessage.AResource{A: [4]byte{192, 0, 2, 10}},
+		&dnsmessage.AAAAResource{AAAA: [16]byte{0x20, 0x01, 0x0d, 0xb8, 15: 1}},
+		&dnsmessage.TXTResource{TXT: []string{"v=spf1 -all"}},
+		&dnsmessage.TXTResource{TXT: []string{"site-verification=abc"}},
+		&dnsmessage.MXResource{Pref: 20, MX: dnsmessage.MustNewName("mx2.example.test.")},
+		&dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mx1.example.test.")},
+	},
+	"www.example.test.": {
+		&dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("app.example.test.")},
+	},
+}
+
+// newStubDNSServer serves zone over UDP on localhost and returns its address.
+// Names missing from zone are answered with NXDOMAIN.
+func newStubDNSServer(t *testing.T, zone stubDNSZone) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if reply, err := zone.reply(buf[:n]); err == nil {
+				_, _ = conn.WriteTo(reply, addr)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func (z stubDNSZone) reply(query []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	header, err := p.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(q.Name.String())
+	records, ok := z[name]
+	header.Response = true
+	header.Authoritative = true
+	if !ok {
+		header.RCode = dnsmessage.RCodeNameError
+	}
+
+	b := dnsmessage.NewBuilder(nil, header)
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+
+	owner := q.Name
+	for len(records) > 0 {
+		var next []dnsmessage.ResourceBody
+		for _, body := range records {
+			hdr := dnsmessage.ResourceHeader{Name: owner, Class: dnsmessage.ClassINET, TTL: 60}
+			if cname, ok := body.(*dnsmessage.CNAMEResource); ok {
+				// Follow the alias like a recursive resolver would.
+				err = b.CNAMEResource(hdr, *cname)
+				owner, next = cname.CNAME, z[strings.ToLower(cname.CNAME.String())]
+			} else if typeOf(body) == q.Type {
+				switch body := body.(type) {
+				case *dnsmessage.AResource:
+					err = b.AResource(hdr, *body)
+				case *dnsmessage.AAAAResource:
+					err = b.AAAAResource(hdr, *body)
+				case *dnsmessage.TXTResource:
+					err = b.TXTResource(hdr, *body)
+				case *dnsmessage.MXResource:
+					err = b.MXResource(hdr, *body)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		records = next
+	}
+	return b.Finish()
+}
+
+func typeOf(body dnsmessage.ResourceBody) dnsmessage.Type {
+	switch body.(type) {
+	case *dnsmessage.AResource:
+		return dnsmessage.TypeA
+	case *dnsmessage.AAAAResource:
+		return dnsmessage.TypeAAAA
+	case *dnsmessage.TXTResource:
+		return dnsmessage.TypeTXT
+	case *dnsmessage.MXResource:
+		return dnsmessage.TypeMX
+	default:
+		return dnsmessage.TypeCNAME
+	}
+}
+
+func TestLookupDNS(t *testing.T) {
+	resolver := nameserverResolver(newStubDNSServer(t, testDNSZone))
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		hostname   string
+		recordType string
+		want       []string
+	}{
+		{"app.example.test", dnsRecordA, []string{"192.0.2.10", "192.0.2.20"}},
+		{"app.example.test", dnsRecordAAAA, []string{"2001:db8::1"}},
+		{"app.example.test", dnsRecordTXT, []string{"site-verification=abc", "v=spf1 -all"}},
+		{"app.example.test", dnsRecordMX, []string{"10 mx1.example.test.", "20 mx2.example.test."}},
+		{"www.example.test", dnsRecordCNAME, []string{"app.example.test."}},
+		{"www.example.test", dnsRecordA, []string{"192.0.2.10", "192.0.2.20"}},
+	} {
+		records, err := lookupDNS(ctx, resolver, tc.hostname, tc.recordType)
+		require.NoError(t, err, "%s %s", tc.recordType, tc.hostname)
+		assert.Equal(t, tc.want, records, "%s %s", tc.recordType, tc.hostname)
+	}
+
+	_, err := lookupDNS(ctx, resolver, "missing.example.test", dnsRecordA)
+	var dnsErr *net.DNSError
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsNotFound)
+}
+
+func TestDNSLookupDataSource(t *testing.T) {
+	nameserver := newStubDNSServer(t, testDNSZone)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_dns_lookup" "app" {
+						hostname = "app.example.test"
+						nameserver = %[1]q
+					}
+
+					data "utility_dns_lookup" "mail" {
+						hostname = "app.example.test"
+						record_type = "MX"
+						nameserver = %[1]q
+					}`, nameserver),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_dns_lookup.app", "id", "app.example.test"),
+					resource.TestCheckResourceAttr("data.utility_dns_lookup.app", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.utility_dns_lookup.app", "records.0", "192.0.2.10"),
+					resource.TestCheckResourceAttr("data.utility_dns_lookup.app", "records.1", "192.0.2.20"),
+					resource.TestCheckResourceAttr("data.utility_dns_lookup.mail", "records.0", "10 mx1.example.test."),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utility_dns_lookup" "missing" {
+						hostname = "missing.example.test"
+						nameserver = %q
+					}`, nameserver),
+				ExpectError: regexp.MustCompile(`DNS Lookup Failed`),
+			},
+		},
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultFileContentMaxBytes is the largest file utility_file_content reads
+// when max_size_bytes is not set.
+const defaultFileContentMaxBytes = 1 << 20
+
+const (
+	fileContentEncodingRaw    = "raw"
+	fileContentEncodingBase64 = "base64"
+)
+
+var _ datasource.DataSource = (*fileContentDataSource)(nil)
+
+type fileContentDataSource struct{}
+
+func NewFileContentDataSource() datasource.DataSource {
+	return &fileContentDataSource{}
+}
+
+func (d *fileContentDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_file_content"
+}
+
+func (d *fileContentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that reads a local file, for embedding small assets such as a certificate or an icon into other resources. The content is stored in the state, so its size is limited by `max_size_bytes`.",
+		Attributes: map[string]schema.Attribute{
+			"filename": schema.StringAttribute{
+				Description: "Path of the file to read.",
+				Required:    true,
+			},
+			"encoding": schema.StringAttribute{
+				Description: "How `content` is encoded: 'raw' for the content as is, which must be valid UTF-8, or 'base64' for binary files (default: raw).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(fileContentEncodingRaw, fileContentEncodingBase64),
+				},
+			},
+			"max_size_bytes": schema.Int64Attribute{
+				Description: "Largest file accepted, in bytes (default: 1048576). Reading fails if the file is larger.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"content": schema.StringAttribute{
+				Description: "Content of the file, encoded as selected by `encoding`.",
+				Computed:    true,
+			},
+			"size_bytes": schema.Int64Attribute{
+				Description: "Size of the file in bytes.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The SHA256 checksum of the file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type fileContentDataSourceModel struct {
+	Filename     types.String `tfsdk:"filename"`
+	Encoding     types.String `tfsdk:"encoding"`
+	MaxSizeBytes types.Int64  `tfsdk:"max_size_bytes"`
+	Content      types.String `tfsdk:"content"`
+	SizeBytes    types.Int64  `tfsdk:"size_bytes"`
+	ID           types.String `tfsdk:"id"`
+}
+
+func (d *fileContentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config fileContentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxSize := int64(defaultFileContentMaxBytes)
+	if !config.MaxSizeBytes.IsNull() {
+		maxSize = config.MaxSizeBytes.ValueInt64()
+	}
+
+	filename := config.Filename.ValueString()
+	info, err := os.Stat(filename)
+	switch {
+	case os.IsNotExist(err):
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "File Not Found", fmt.Sprintf("%s does not exist.", filename))
+		return
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Reading File Failed", err.Error())
+		return
+	case !info.Mode().IsRegular():
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Not A Regular File", fmt.Sprintf("%s is not a regular file.", filename))
+		return
+	case info.Size() > maxSize:
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "File Too Large", fmt.Sprintf("%s is %d bytes, which is more than max_size_bytes (%d).", filename, info.Size(), maxSize))
+		return
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Reading File Failed", err.Error())
+		return
+	}
+	defer f.Close()
+
+	content, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Reading File Failed", err.Error())
+		return
+	}
+	if int64(len(content)) > maxSize {
+		// The file grew since it was checked.
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "File Too Large", fmt.Sprintf("%s is more than max_size_bytes (%d) bytes.", filename, maxSize))
+		return
+	}
+
+	switch config.Encoding.ValueString() {
+	case fileContentEncodingBase64:
+		config.Content = types.StringValue(base64.StdEncoding.EncodeToString(content))
+	default:
+		if !utf8.Valid(content) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("encoding"),
+				"Invalid UTF-8",
+				fmt.Sprintf("%s is not valid UTF-8 text. Set encoding to %q to read binary files.", filename, fileContentEncodingBase64),
+			)
+			return
+		}
+		config.Content = types.StringValue(string(content))
+	}
+
+	sum := sha256.Sum256(content)
+	config.SizeBytes = types.Int64Value(int64(len(content)))
+	config.ID = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileContentDataSource(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "motd.txt")
+	require.NoError(t, os.WriteFile(text, []byte("héllo\n"), 0o644))
+	binary := filepath.Join(dir, "icon.bin")
+	require.NoError(t, os.WriteFile(binary, []byte{0x89, 'P', 'N', 'G', 0xff}, 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_file_content" "motd" {
+						filename = %q
+					}
+
+					data "utility_file_content" "icon" {
+						filename = %q
+						encoding = "base64"
+					}`, text, binary),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_file_content.motd", "content", "héllo\n"),
+					resource.TestCheckResourceAttr("data.utility_file_content.motd", "size_bytes", "7"),
+					resource.TestCheckResourceAttr("data.utility_file_content.icon", "content", "iVBOR/8="),
+					resource.TestCheckResourceAttr("data.utility_file_content.icon", "size_bytes", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestFileContentDataSource_Rejects(t *testing.T) {
+	binary := filepath.Join(t.TempDir(), "icon.bin")
+	require.NoError(t, os.WriteFile(binary, []byte{0x89, 'P', 'N', 'G', 0xff}, 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_file_content" "binary_raw" {
+						filename = %q
+					}`, binary),
+				ExpectError: regexp.MustCompile(`is not valid UTF-8`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utility_file_content" "too_large" {
+						filename = %q
+						encoding = "base64"
+						max_size_bytes = 4
+					}`, binary),
+				ExpectError: regexp.MustCompile(`more than max_size_bytes \(4\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*fileHashDataSource)(nil)
+
+type fileHashDataSource struct{}
+
+func NewFileHashDataSource() datasource.DataSource {
+	return &fileHashDataSource{}
+}
+
+func (d *fileHashDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_file_hash"
+}
+
+func (d *fileHashDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that computes the checksums of an existing local file, such as one checked out from git, without downloading it. The file is streamed while hashing, so large files are not held in memory.",
+		Attributes: map[string]schema.Attribute{
+			"filename": schema.StringAttribute{
+				Description: "Path of the file to hash.",
+				Required:    true,
+			},
+			"sha1": schema.StringAttribute{
+				Description: "SHA1 checksum of the file.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the file.",
+				Computed:    true,
+			},
+			"md5": schema.StringAttribute{
+				Description: "MD5 checksum of the file, for comparing against manifests that still use it. Prefer `sha256` for anything else.",
+				Computed:    true,
+			},
+			"size_bytes": schema.Int64Attribute{
+				Description: "Size of the file in bytes.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The SHA256 checksum of the file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type fileHashDataSourceModel struct {
+	Filename  types.String `tfsdk:"filename"`
+	Sha1      types.String `tfsdk:"sha1"`
+	Sha256    types.String `tfsdk:"sha256"`
+	MD5       types.String `tfsdk:"md5"`
+	SizeBytes types.Int64  `tfsdk:"size_bytes"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func (d *fileHashDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config fileHashDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filename := config.Filename.ValueString()
+	info, err := os.Stat(filename)
+	switch {
+	case os.IsNotExist(err):
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "File Not Found", fmt.Sprintf("%s does not exist.", filename))
+		return
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Reading File Failed", err.Error())
+		return
+	case !info.Mode().IsRegular():
+		resp.Diagnostics.AddAttributeError(path.Root("filename"), "Not A Regular File", fmt.Sprintf("%s is not a regular file.", filename))
+		return
+	}
+
+	checksums, err := hashFile(filename, checksumMD5)
+	if err != nil {
+		resp.Diagnostics.AddError("Hashing File Failed", err.Error())
+		return
+	}
+
+	config.Sha1 = types.StringValue(checksums.sha1Hex)
+	config.Sha256 = types.StringValue(checksums.sha256Hex)
+	config.MD5 = types.StringValue(checksums.get(checksumMD5))
+	config.SizeBytes = types.Int64Value(info.Size())
+	config.ID = config.Sha256
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileHashDataSource(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("hello world"), 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_file_hash" "app" {
+						filename = %q
+					}`, filename),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_file_hash.app", "sha1", "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed"),
+					resource.TestCheckResourceAttr("data.utility_file_hash.app", "sha256", "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"),
+					resource.TestCheckResourceAttr("data.utility_file_hash.app", "md5", "5eb63bbbe01eeed093cb22bb8f5acdc3"),
+					resource.TestCheckResourceAttr("data.utility_file_hash.app", "size_bytes", "11"),
+					resource.TestCheckResourceAttr("data.utility_file_hash.app", "id", "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"),
+				),
+			},
+		},
+	})
+}
+
+func TestFileHashDataSource_Missing(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "missing.yaml")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_file_hash" "missing" {
+						filename = %q
+					}`, filename),
+				ExpectError: regexp.MustCompile(`does not exist`),
+			},
+		},
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultResponseBodyMaxBytes is the largest response body utility_http
+// accepts when response_body_max_bytes is not set.
+const defaultResponseBodyMaxBytes = 1 << 20
+
+var _ datasource.DataSource = (*httpDataSource)(nil)
+
+type httpDataSource struct{}
+
+func NewHTTPDataSource() datasource.DataSource {
+	return &httpDataSource{}
+}
+
+func (d *httpDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_http"
+}
+
+func (d *httpDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that sends an HTTP request and exposes the response, for feeding small documents such as a JSON config into other resources without writing them to disk. The response is kept in memory, so its size is limited by `response_body_max_bytes`. Unlike `utility_file_downloader`, responses with a status other than 200 are not an error; check `status_code` instead.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "URL to send the request to.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method to use for the request (default: GET). Only 'GET' and 'POST' are allowed.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPost),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in the request. The map key is the header name, and the value is the header content, in which `$${env:NAME}` is replaced with the environment variable `NAME`, or `$${env:NAME:-default}` with `default` if it is unset or empty.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"request_body": schema.StringAttribute{
+				Description: "Body to send with the request, with the Content-Type application/octet-stream unless `headers` sets one.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"response_body_max_bytes": schema.Int64Attribute{
+				Description: "Largest response body accepted, in bytes (default: 1048576). Reading fails if the body is larger.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"response_body": schema.StringAttribute{
+				Description: "Body of the response.",
+				Computed:    true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "HTTP status code of the response.",
+				Computed:    true,
+			},
+			"response_headers": schema.MapAttribute{
+				Description: "Headers of the response. Repeated headers are joined with \", \".",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description: "The requested URL.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type httpDataSourceModel struct {
+	URL                  types.String `tfsdk:"url"`
+	Method               types.String `tfsdk:"method"`
+	Headers              types.Map    `tfsdk:"headers"`
+	RequestBody          types.String `tfsdk:"request_body"`
+	ResponseBodyMaxBytes types.Int64  `tfsdk:"response_body_max_bytes"`
+	ResponseBody         types.String `tfsdk:"response_body"`
+	StatusCode           types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders      types.Map    `tfsdk:"response_headers"`
+	ID                   types.String `tfsdk:"id"`
+}
+
+func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config httpDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := http.MethodGet
+	if config.Method.ValueString() != "" {
+		method = strings.ToUpper(config.Method.ValueString())
+	}
+	maxBytes := int64(defaultResponseBodyMaxBytes)
+	if !config.ResponseBodyMaxBytes.IsNull() {
+		maxBytes = config.ResponseBodyMaxBytes.ValueInt64()
+	}
+
+	opts := downloadOptions{
+		method:  method,
+		url:     config.URL.ValueString(),
+		headers: stringMapValue(config.Headers),
+		body:    config.RequestBody.ValueString(),
+	}
+	result, err := fetchResponse(ctx, opts, maxBytes)
+	if err != nil {
+		resp.Diagnostics.AddError("HTTP Request Failed", err.Error())
+		return
+	}
+
+	headers, diags := types.MapValueFrom(ctx, types.StringType, result.headers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ResponseBody = types.StringValue(string(result.body))
+	config.StatusCode = types.Int64Value(int64(result.status))
+	config.ResponseHeaders = headers
+	config.ID = config.URL
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
+
+// httpResponse is a response read completely into memory.
+type httpResponse struct {
+	status  int
+	headers map[string]string
+	body    []byte
+}
+
+// fetchResponse sends the request described by opts and reads the response
+// into memory. Any status is returned rather than treated as an error, but
+// a body longer than maxBytes is.
+func fetchResponse(ctx context.Context, opts downloadOptions, maxBytes int64) (*httpResponse, error) {
+	req, err := newRequest(ctx, opts, opts.url)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, fmt.Errorf("response body is larger than response_body_max_bytes (%d bytes)", maxBytes)
+	}
+
+	return &httpResponse{
+		status:  resp.StatusCode,
+		headers: responseHeaders(resp),
+		body:    body,
+	}, nil
+}
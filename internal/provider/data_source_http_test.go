@@ -0,0 +1,109 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("X-Region", "eu")
+		w.Header().Add("X-Region", "us")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"method":%q,"token":%q,"body":%q}`, r.Method, r.Header.Get("X-Token"), body)
+	}))
+	defer ts.Close()
+
+	result, err := fetchResponse(context.Background(), downloadOptions{
+		method:  http.MethodPost,
+		url:     ts.URL,
+		headers: map[string]string{"X-Token": "abc"},
+		body:    "ping",
+	}, defaultResponseBodyMaxBytes)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, result.status)
+	assert.Equal(t, `{"method":"POST","token":"abc","body":"ping"}`, string(result.body))
+	assert.Equal(t, "eu, us", result.headers["X-Region"])
+	assert.Equal(t, "application/json", result.headers["Content-Type"])
+}
+
+func TestFetchResponse_MaxBytes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 11)))
+	}))
+	defer ts.Close()
+
+	opts := downloadOptions{method: http.MethodGet, url: ts.URL}
+	result, err := fetchResponse(context.Background(), opts, 11)
+	require.NoError(t, err)
+	assert.Len(t, result.body, 11)
+
+	_, err = fetchResponse(context.Background(), opts, 10)
+	assert.ErrorContains(t, err, "larger than response_body_max_bytes")
+}
+
+func TestHTTPDataSource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"replicas":3}`))
+	}))
+	defer ts.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_http" "config" {
+						url = "%s"
+						headers = {
+							Authorization = "Bearer abc"
+						}
+					}`, ts.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_http.config", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utility_http.config", "response_body", `{"replicas":3}`),
+					resource.TestCheckResourceAttr("data.utility_http.config", "response_headers.Content-Type", "application/json"),
+					resource.TestCheckResourceAttr("data.utility_http.config", "id", ts.URL),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utility_http" "config" {
+						url = "%s"
+					}`, ts.URL),
+				Check: resource.TestCheckResourceAttr("data.utility_http.config", "status_code", "401"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utility_http" "config" {
+						url = "%s"
+						response_body_max_bytes = 4
+						headers = {
+							Authorization = "Bearer abc"
+						}
+					}`, ts.URL),
+				ExpectError: regexp.MustCompile(`larger than response_body_max_bytes`),
+			},
+		},
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultPortCheckTimeout is how long utility_port_check waits for a
+// connection when timeout is not set.
+const defaultPortCheckTimeout = 5 * time.Second
+
+var _ datasource.DataSource = (*portCheckDataSource)(nil)
+
+type portCheckDataSource struct{}
+
+func NewPortCheckDataSource() datasource.DataSource {
+	return &portCheckDataSource{}
+}
+
+func (d *portCheckDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_port_check"
+}
+
+func (d *portCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that tries once to open a TCP connection, for example to check during plan that a service is reachable before configuring resources that depend on it. An unreachable port is not an error: `reachable` is false instead. Use `utility_wait_for_port` to wait until the port opens.",
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description: "The host name or IP address to connect to.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description: "The TCP port to connect to.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the connection, as a duration such as \"5s\" (default: 5s).",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"reachable": schema.BoolAttribute{
+				Description: "Whether the connection succeeded within the timeout.",
+				Computed:    true,
+			},
+			"latency_ms": schema.Int64Attribute{
+				Description: "Time taken to establish the connection, in milliseconds. Null if the port is not reachable.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The dialed address, as `host:port`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type portCheckDataSourceModel struct {
+	Host      types.String `tfsdk:"host"`
+	Port      types.Int64  `tfsdk:"port"`
+	Timeout   types.String `tfsdk:"timeout"`
+	Reachable types.Bool   `tfsdk:"reachable"`
+	LatencyMs types.Int64  `tfsdk:"latency_ms"`
+	ID        types.String `tfsdk:"id"`
+}
+
+func (d *portCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config portCheckDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := defaultPortCheckTimeout
+	if !config.Timeout.IsNull() {
+		timeout, _ = time.ParseDuration(config.Timeout.ValueString())
+	}
+
+	address := net.JoinHostPort(config.Host.ValueString(), strconv.FormatInt(config.Port.ValueInt64(), 10))
+	dialer := net.Dialer{Timeout: timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		tflog.Debug(ctx, "Port is not reachable", map[string]any{"address": address, "error": err.Error()})
+		config.Reachable = types.BoolValue(false)
+		config.LatencyMs = types.Int64Null()
+	} else {
+		conn.Close()
+		config.Reachable = types.BoolValue(true)
+		config.LatencyMs = types.Int64Value(time.Since(start).Milliseconds())
+	}
+	config.ID = types.StringValue(address)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
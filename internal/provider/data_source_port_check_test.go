@@ -0,0 +1,81 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortCheckDataSource(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+	host, port, err := net.SplitHostPort(ts.Listener.Addr().String())
+	require.NoError(t, err)
+
+	// Reserve a port, then close it so nothing is listening on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, closedPort, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_port_check" "open" {
+						host = %[1]q
+						port = %[2]s
+					}
+
+					data "utility_port_check" "closed" {
+						host = %[1]q
+						port = %[3]s
+						timeout = "1s"
+					}`, host, port, closedPort),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_port_check.open", "reachable", "true"),
+					resource.TestCheckResourceAttrSet("data.utility_port_check.open", "latency_ms"),
+					resource.TestCheckResourceAttr("data.utility_port_check.open", "id", net.JoinHostPort(host, port)),
+					resource.TestCheckResourceAttr("data.utility_port_check.closed", "reachable", "false"),
+					resource.TestCheckNoResourceAttr("data.utility_port_check.closed", "latency_ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestPortCheckDataSource_Invalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "utility_port_check" "bad_port" {
+						host = "127.0.0.1"
+						port = 70000
+					}`,
+				ExpectError: regexp.MustCompile(`must be between 1 and 65535`),
+			},
+			{
+				Config: `
+					data "utility_port_check" "bad_timeout" {
+						host = "127.0.0.1"
+						port = 80
+						timeout = "soon"
+					}`,
+				ExpectError: regexp.MustCompile(`Invalid Duration`),
+			},
+		},
+	})
+}
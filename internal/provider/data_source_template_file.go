@@ -0,0 +1,103 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*templateFileDataSource)(nil)
+
+type templateFileDataSource struct{}
+
+func NewTemplateFileDataSource() datasource.DataSource {
+	return &templateFileDataSource{}
+}
+
+func (d *templateFileDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_template_file"
+}
+
+func (d *templateFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that renders a Go `text/template` with variables, e.g. `{{ .region }}`. Besides the builtins of `text/template`, templates can use `upper`, `lower`, `trim`, `trimPrefix`, `trimSuffix`, `replace`, `split`, `join`, `quote`, `default`, `filename` (the last element of a slash separated path) and `dirname` (all but the last element). Arguments come first and the value last, so they work in pipelines such as `{{ .name | trimSuffix \".zip\" | upper }}`. Referencing a variable that is not set is an error.",
+		Attributes: map[string]schema.Attribute{
+			"template": schema.StringAttribute{
+				Description: "The template to render. Exactly one of `template` and `template_path` is required.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("template_path")),
+				},
+			},
+			"template_path": schema.StringAttribute{
+				Description: "Path of a file holding the template to render.",
+				Optional:    true,
+			},
+			"vars": schema.MapAttribute{
+				Description: "Variables available to the template.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"rendered": schema.StringAttribute{
+				Description: "The rendered template.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "SHA256 checksum of `rendered`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type templateFileDataSourceModel struct {
+	Template     types.String `tfsdk:"template"`
+	TemplatePath types.String `tfsdk:"template_path"`
+	Vars         types.Map    `tfsdk:"vars"`
+	Rendered     types.String `tfsdk:"rendered"`
+	ID           types.String `tfsdk:"id"`
+}
+
+func (d *templateFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config templateFileDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := "template"
+	text := []byte(config.Template.ValueString())
+	if !config.TemplatePath.IsNull() {
+		var err error
+		name = filepath.Base(config.TemplatePath.ValueString())
+		text, err = os.ReadFile(config.TemplatePath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("template_path"), "Reading Template Failed", err.Error())
+			return
+		}
+	}
+
+	rendered, err := renderTemplate(name, text, stringMapValue(config.Vars))
+	if err != nil {
+		resp.Diagnostics.AddError("Rendering Template Failed", err.Error())
+		return
+	}
+
+	sum := sha256.Sum256(rendered)
+	config.Rendered = types.StringValue(string(rendered))
+	config.ID = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFileDataSource(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "app.yaml.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("region: {{ .region }}\n"), 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_template_file" "inline" {
+						template = "{{ .name | upper }} ships {{ .artifact | filename }}"
+						vars = {
+							name = "api"
+							artifact = "dist/api.tar.gz"
+						}
+					}
+
+					data "utility_template_file" "file" {
+						template_path = %q
+						vars = {
+							region = "eu-west-1"
+						}
+					}`, templatePath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_template_file.inline", "rendered", "API ships api.tar.gz"),
+					resource.TestCheckResourceAttr("data.utility_template_file.file", "rendered", "region: eu-west-1\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestTemplateFileDataSource_MissingKey(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "utility_template_file" "missing" {
+						template = "{{ .region }}"
+						vars = {
+							zone = "a"
+						}
+					}`,
+				ExpectError: regexp.MustCompile(`map has no entry for key "region"`),
+			},
+		},
+	})
+}
+
+func TestRenderTemplate(t *testing.T) {
+	vars := map[string]string{"name": "api", "path": "releases/v1/api.zip", "empty": ""}
+
+	for text, want := range map[string]string{
+		`{{ .name | upper }}`:                        "API",
+		`{{ "API" | lower }}`:                        "api",
+		`{{ .path | filename }}`:                     "api.zip",
+		`{{ .path | dirname }}`:                      "releases/v1",
+		`{{ .path | filename | trimSuffix ".zip" }}`: "api",
+		`{{ .path | trimPrefix "releases/" }}`:       "v1/api.zip",
+		`{{ .path | replace "/" "-" }}`:              "releases-v1-api.zip",
+		`{{ .path | split "/" | join "," }}`:         "releases,v1,api.zip",
+		`{{ .empty | default "none" }}`:              "none",
+		`{{ .name | quote }}`:                        `"api"`,
+		`{{ "  padded " | trim }}`:                   "padded",
+		`{{ printf "%s-%d" .name (len .name) }}`:     "api-3",
+	} {
+		got, err := renderTemplate("test", []byte(text), vars)
+		require.NoError(t, err, text)
+		assert.Equal(t, want, string(got), text)
+	}
+
+	_, err := renderTemplate("test", []byte("{{ .region }}"), vars)
+	assert.ErrorContains(t, err, `map has no entry for key "region"`)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*urlHeadDataSource)(nil)
+
+type urlHeadDataSource struct{}
+
+func NewURLHeadDataSource() datasource.DataSource {
+	return &urlHeadDataSource{}
+}
+
+func (d *urlHeadDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = "utility_url_head"
+}
+
+func (d *urlHeadDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source that checks whether a remote URL exists, and how large it is, without downloading it. Any response, including a 404, is a valid result: check `exists` or `status_code`. Reading only fails if no response is received, such as when the connection is refused. Redirects are followed.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "URL to check.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method to use for the request (default: HEAD). Use 'GET' for servers that do not answer HEAD requests properly; the body of the response is not read.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodHead, http.MethodGet),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in the request. The map key is the header name, and the value is the header content. Environment variables can be referenced as in the `utility_http` data source.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "HTTP status code of the response.",
+				Computed:    true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the status code is a 2xx.",
+				Computed:    true,
+			},
+			"content_length": schema.Int64Attribute{
+				Description: "The `Content-Length` of the response, in bytes. Null if the server did not send one.",
+				Computed:    true,
+			},
+			"content_type": schema.StringAttribute{
+				Description: "The `Content-Type` of the response. Null if the server did not send one.",
+				Computed:    true,
+			},
+			"last_modified": schema.StringAttribute{
+				Description: "The `Last-Modified` header of the response, as sent by the server. Null if the server did not send one.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The requested URL.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type urlHeadDataSourceModel struct {
+	URL           types.String `tfsdk:"url"`
+	Method        types.String `tfsdk:"method"`
+	Headers       types.Map    `tfsdk:"headers"`
+	StatusCode    types.Int64  `tfsdk:"status_code"`
+	Exists        types.Bool   `tfsdk:"exists"`
+	ContentLength types.Int64  `tfsdk:"content_length"`
+	ContentType   types.String `tfsdk:"content_type"`
+	LastModified  types.String `tfsdk:"last_modified"`
+	ID            types.String `tfsdk:"id"`
+}
+
+func (d *urlHeadDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config urlHeadDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := http.MethodHead
+	if config.Method.ValueString() != "" {
+		method = strings.ToUpper(config.Method.ValueString())
+	}
+
+	opts := downloadOptions{
+		method:  method,
+		url:     config.URL.ValueString(),
+		headers: stringMapValue(config.Headers),
+	}
+	result, err := fetchHead(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("HTTP Request Failed", err.Error())
+		return
+	}
+
+	config.StatusCode = types.Int64Value(int64(result.StatusCode))
+	config.Exists = types.BoolValue(result.StatusCode >= 200 && result.StatusCode < 300)
+	config.ContentLength = types.Int64Null()
+	if result.ContentLength >= 0 {
+		config.ContentLength = types.Int64Value(result.ContentLength)
+	}
+	config.ContentType = optionalString(result.Header.Get("Content-Type"))
+	config.LastModified = optionalString(result.Header.Get("Last-Modified"))
+	config.ID = config.URL
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, config)...)
+}
+
+// fetchHead sends the request described by opts and returns the response
+// with its body closed unread, so that only the status and headers are
+// available.
+func fetchHead(ctx context.Context, opts downloadOptions) (*http.Response, error) {
+	req, err := newRequest(ctx, opts, opts.url)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newURLHeadServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app.zip" || r.Header.Get("X-Token") != "abc" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/zip")
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Length", "1024")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(make([]byte, 1024))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestURLHeadDataSource(t *testing.T) {
+	ts := newURLHeadServer(t)
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "utility_url_head" "found" {
+						url = "%[1]s/app.zip"
+						headers = {
+							X-Token = "abc"
+						}
+					}
+
+					data "utility_url_head" "missing" {
+						url = "%[1]s/missing.zip"
+						method = "GET"
+					}`, ts.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.utility_url_head.found", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.utility_url_head.found", "exists", "true"),
+					resource.TestCheckResourceAttr("data.utility_url_head.found", "content_length", "1024"),
+					resource.TestCheckResourceAttr("data.utility_url_head.found", "content_type", "application/zip"),
+					resource.TestCheckResourceAttr("data.utility_url_head.found", "last_modified", "Mon, 02 Jan 2006 15:04:05 GMT"),
+					resource.TestCheckResourceAttr("data.utility_url_head.missing", "status_code", "404"),
+					resource.TestCheckResourceAttr("data.utility_url_head.missing", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.utility_url_head.missing", "last_modified"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "utility_url_head" "unreachable" {
+						url = "%s/app.zip"
+					}`, closed.URL),
+				ExpectError: regexp.MustCompile(`HTTP Request Failed`),
+			},
+		},
+	})
+}
+
+func TestFetchHead(t *testing.T) {
+	ts := newURLHeadServer(t)
+	headers := map[string]string{"X-Token": "abc"}
+
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		resp, err := fetchHead(context.Background(), downloadOptions{method: method, url: ts.URL + "/app.zip", headers: headers})
+		require.NoError(t, err, method)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, method)
+		assert.Equal(t, int64(1024), resp.ContentLength, method)
+	}
+
+	resp, err := fetchHead(context.Background(), downloadOptions{method: http.MethodHead, url: ts.URL + "/missing.zip"})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+	_, err = fetchHead(context.Background(), downloadOptions{method: http.MethodHead, url: closed.URL})
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	symlinkPolicyLink   = "link"
+	symlinkPolicyFollow = "follow"
+	symlinkPolicySkip   = "skip"
+)
+
+// directoryChecksum is the result of hashTree.
+type directoryChecksum struct {
+	// sha256Hex is the aggregate checksum of the tree.
+	sha256Hex string
+
+	// files maps the slash separated path of every file relative to the
+	// root to the SHA256 of its content.
+	files map[string]string
+}
+
+// hashTree walks the tree below root and hashes every file. The
+// aggregate checksum covers the sorted relative paths and the checksums of
+// their contents, so it changes when a file is added, removed, renamed or
+// modified, but not when only modification times change. Directories are
+// only represented by the files they contain.
+//
+// symlinks selects how symbolic links are treated: "link" hashes the path
+// they point to, "follow" hashes the content of the file they point to and
+// "skip" ignores them. Links to directories are never descended into.
+func hashTree(root, symlinks string) (*directoryChecksum, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		sum, ok, err := hashTreeEntry(path, d, symlinks)
+		if err != nil {
+			return fmt.Errorf("hashing %s: %w", rel, err)
+		}
+		if ok {
+			files[rel] = sum
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &directoryChecksum{
+		sha256Hex: aggregateChecksum(files),
+		files:     files,
+	}, nil
+}
+
+// aggregateChecksum returns the SHA256 over the sorted relative paths in
+// files and the checksums they map to.
+func aggregateChecksum(files map[string]string) string {
+	h := sha256.New()
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		// NUL cannot appear in a path, so entries cannot run into each
+		// other.
+		fmt.Fprintf(h, "%s\x00%s\n", name, files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashTreeEntry returns the checksum of a non-directory entry, or false
+// if it is left out of the tree checksum.
+func hashTreeEntry(path string, d fs.DirEntry, symlinks string) (string, bool, error) {
+	if d.Type()&fs.ModeSymlink == 0 {
+		if !d.Type().IsRegular() {
+			// Sockets, devices and pipes have no content to hash.
+			return "", false, nil
+		}
+		checksums, err := hashFile(path)
+		if err != nil {
+			return "", false, err
+		}
+		return checksums.sha256Hex, true, nil
+	}
+
+	switch symlinks {
+	case symlinkPolicySkip:
+		return "", false, nil
+	case symlinkPolicyFollow:
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", false, err
+		}
+		if !info.Mode().IsRegular() {
+			return "", false, nil
+		}
+		checksums, err := hashFile(path)
+		if err != nil {
+			return "", false, err
+		}
+		return checksums.sha256Hex, true, nil
+	default:
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", false, err
+		}
+		// Prefix the target so a link cannot collide with a file whose
+		// content checksum happens to equal it.
+		sum := sha256.Sum256([]byte("symlink:" + filepath.ToSlash(target)))
+		return hex.EncodeToString(sum[:]), true, nil
+	}
+}
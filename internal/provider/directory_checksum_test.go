@@ -0,0 +1,56 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashTree(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "conf", "empty"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "app.txt"), []byte("app"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "conf", "app.yaml"), []byte("v1"), 0o644))
+	require.NoError(t, os.Symlink("app.txt", filepath.Join(root, "current")))
+
+	sum, err := hashTree(root, symlinkPolicyLink)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"app.txt", "conf/app.yaml", "current"}, slices.Collect(maps.Keys(sum.files)))
+	appSum := sha256.Sum256([]byte("app"))
+	assert.Equal(t, hex.EncodeToString(appSum[:]), sum.files["app.txt"])
+
+	followed, err := hashTree(root, symlinkPolicyFollow)
+	require.NoError(t, err)
+	assert.Equal(t, sum.files["app.txt"], followed.files["current"])
+	assert.NotEqual(t, sum.sha256Hex, followed.sha256Hex)
+
+	skipped, err := hashTree(root, symlinkPolicySkip)
+	require.NoError(t, err)
+	assert.NotContains(t, skipped.files, "current")
+
+	// Only content and paths count, not modification times.
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "app.txt"), old, old))
+	again, err := hashTree(root, symlinkPolicyLink)
+	require.NoError(t, err)
+	assert.Equal(t, sum.sha256Hex, again.sha256Hex)
+
+	require.NoError(t, os.Rename(filepath.Join(root, "conf", "app.yaml"), filepath.Join(root, "conf", "app.yml")))
+	renamed, err := hashTree(root, symlinkPolicyLink)
+	require.NoError(t, err)
+	assert.NotEqual(t, sum.sha256Hex, renamed.sha256Hex)
+
+	_, err = hashTree(filepath.Join(root, "missing"), symlinkPolicyLink)
+	assert.Error(t, err)
+}
@@ -0,0 +1,1630 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	lineEndingsPreserve = "preserve"
+	lineEndingsLF       = "lf"
+	lineEndingsCRLF     = "crlf"
+)
+
+const (
+	decompressNone = "none"
+	decompressGzip = "gzip"
+	decompressAuto = "auto"
+)
+
+// defaultRetryWait is the time waited before the first retry when no
+// retry_wait is configured.
+const defaultRetryWait = time.Second
+
+type downloadOptions struct {
+	method          string
+	url             string
+	path            string
+	headers         map[string]string
+	body            string
+	bodyContentType string
+	hashChunkSize   int
+	resolveSymlinks bool
+	trailers        map[string]string
+	pagination      paginationOptions
+
+	// checksumAlgorithms selects extra checksums as in newFileHasher.
+	checksumAlgorithms []string
+
+	// templateVars, when non-nil, renders text responses as Go templates
+	// with these variables before they are written.
+	templateVars map[string]string
+
+	// lineEndings normalizes the line endings of text responses to LF or
+	// CRLF before they are written. forceText treats every response as
+	// text for templateVars and lineEndings.
+	lineEndings string
+	forceText   bool
+
+	// decompress selects how the body is decompressed before it is hashed
+	// and written: not at all, always as gzip, or as announced by the
+	// Content-Encoding header.
+	decompress string
+
+	// disableRedirects stops redirects from being followed. A 3xx response
+	// is then a successful result that writes no file.
+	disableRedirects bool
+
+	// maxSize, when set, is the largest size in bytes the file may have.
+	// The download fails as soon as more is written, or before anything is
+	// written if the server announces a larger Content-Length.
+	maxSize *int64
+
+	// expectedStatusCodes lists the status codes of a successful response.
+	// Empty means 200 OK only.
+	expectedStatusCodes []int
+
+	// maxBytesPerSecond, when positive, limits how fast response bodies are
+	// read. Zero means no limit.
+	maxBytesPerSecond int64
+
+	// maxRedirects, when set, limits the number of redirects followed,
+	// failing the request with errTooManyRedirects beyond it. Nil keeps the
+	// limit of net/http, which stops after 10 requests.
+	maxRedirects *int
+
+	// extraPaths receive a copy of the content written to path. They are
+	// written atomically, and if the download fails all paths are removed.
+	extraPaths []string
+
+	// timeout limits each request including reading its body. Zero means
+	// no limit.
+	timeout time.Duration
+
+	// sourceAddress, when set, is the local IP outgoing connections are
+	// bound to.
+	sourceAddress string
+
+	// caCertPEM and caCertFile hold CA certificates that server certificates
+	// are verified against. They replace the system roots unless
+	// caCertAppend is set.
+	caCertPEM    string
+	caCertFile   string
+	caCertAppend bool
+
+	// insecureSkipVerify disables the verification of server certificates.
+	insecureSkipVerify bool
+
+	// clientCertPEM and clientKeyPEM, when set, hold the PEM encoded
+	// certificate and private key presented to servers that request a
+	// client certificate. Either both or neither are set.
+	clientCertPEM string
+	clientKeyPEM  string
+
+	// proxyURL is the proxy requests are sent through. When empty, the
+	// proxy is taken from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables.
+	proxyURL string
+
+	// ifNoneMatch, when set, is sent as If-None-Match. A 304 Not Modified
+	// response then leaves the existing file untouched.
+	ifNoneMatch string
+
+	// ifModifiedSince, when set, is sent as If-Modified-Since, for servers
+	// that do not derive their ETags from the content.
+	ifModifiedSince time.Time
+
+	// failIfExists makes the download fail instead of overwriting an
+	// existing file at path.
+	failIfExists bool
+
+	// resume writes the content to the partial file of path, which is kept
+	// if the download fails. A later download with resume requests only the
+	// bytes that are missing from an existing partial file, which it sets
+	// rangeStart to.
+	resume     bool
+	rangeStart int64
+
+	// serverFilename writes the file into path, if it is an existing
+	// directory, under the name given by the server or the URL.
+	serverFilename bool
+
+	// userAgent, when set, is sent as User-Agent unless headers has one.
+	userAgent string
+
+	// basicAuth and bearerToken, when set, are sent as the Authorization
+	// header. At most one of them is set.
+	basicAuth   *basicAuth
+	bearerToken string
+
+	// retryMax is the number of times a request is retried after a
+	// connection error or a 5xx response, waiting retryWait before the
+	// first retry and twice as long before each further one. Zero
+	// retryWait selects defaultRetryWait.
+	retryMax  int
+	retryWait time.Duration
+
+	// fileMode and dirMode are the permissions of the written file and of
+	// directories created for it. Zero selects 0644 and 0755.
+	fileMode os.FileMode
+	dirMode  os.FileMode
+
+	// verify, when set, checks the content received into the temporary or
+	// partial file at path before it replaces the files of an earlier
+	// download. An error fails the download and the content is discarded.
+	// After a 304 Not Modified response, path is the existing file, which
+	// is kept.
+	verify func(path string, result *downloadResult) error
+}
+
+// basicAuth holds the credentials for HTTP basic authentication.
+type basicAuth struct {
+	username string
+	password string
+}
+
+// paginationOptions describes how to find the next page of a paginated
+// response. Pagination is disabled when neither source is set.
+type paginationOptions struct {
+	nextHeader    string
+	nextJSONField string
+	maxPages      int
+}
+
+func (p paginationOptions) enabled() bool {
+	return p.nextHeader != "" || p.nextJSONField != ""
+}
+
+type downloadResult struct {
+	*fileChecksums
+
+	// status and headers describe the response the file was written from,
+	// or the first page of a paginated download.
+	status  int
+	headers map[string]string
+
+	// contentLengthVerified is nil when the server did not advertise a
+	// Content-Length.
+	contentLengthVerified *bool
+
+	// trailers holds the response trailers, which are only known once the
+	// body has been read to EOF.
+	trailers map[string]string
+
+	pagesFetched int
+
+	// bytesReceived is the length of the response bodies, before any
+	// template rendering or line ending normalization.
+	bytesReceived int64
+
+	etag         string
+	lastModified string
+
+	// path is the file the content was written to, which differs from the
+	// requested path when it is named by the server. Empty for redirects.
+	path string
+
+	// timeline records every HTTP request attempt made for the download.
+	timeline []requestAttempt
+
+	// notModified is set when the server answered a conditional request
+	// with 304 Not Modified. The checksums are then those of the existing
+	// file.
+	notModified bool
+
+	// redirected is set when redirects are disabled and the server answered
+	// with a redirect to redirectLocation. No file was written and the
+	// checksums are nil.
+	redirected       bool
+	redirectLocation string
+}
+
+func downloadFile(ctx context.Context, limiter *hostLimiter, opts downloadOptions) (_ *downloadResult, err error) {
+	tflog.Debug(ctx, "Downloading file", map[string]any{"method": opts.method})
+
+	opts.rangeStart = 0
+	if info, err := os.Stat(resumePartPath(opts.path)); opts.resume && err == nil && info.Mode().IsRegular() {
+		opts.rangeStart = info.Size()
+		tflog.Debug(ctx, "Resuming partial download", map[string]any{"offset": opts.rangeStart})
+	}
+
+	var timeline []requestAttempt
+	resp, release, err := sendRequest(ctx, limiter, opts, opts.url, &timeline)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// The partial file is complete or no longer matches the remote
+		// file, so download the whole file again.
+		resp.Body.Close()
+		release()
+		if err := os.Remove(resumePartPath(opts.path)); err != nil {
+			return nil, err
+		}
+		return downloadFile(ctx, limiter, opts)
+	}
+	// Paginated downloads free the slot early, before requesting the next
+	// page from the same host.
+	release = sync.OnceFunc(release)
+	defer release()
+	defer resp.Body.Close()
+
+	if isRedirect(resp.StatusCode) {
+		tflog.Debug(ctx, "Not following redirect", map[string]any{"status": resp.StatusCode})
+		return &downloadResult{
+			status:           resp.StatusCode,
+			headers:          responseHeaders(resp),
+			trailers:         map[string]string{},
+			timeline:         timeline,
+			redirected:       true,
+			redirectLocation: resp.Header.Get("Location"),
+		}, nil
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		checksums, err := hashFile(opts.path, opts.checksumAlgorithms...)
+		if err != nil {
+			return nil, err
+		}
+
+		etag := resp.Header.Get("ETag")
+		if etag == "" {
+			etag = opts.ifNoneMatch
+		}
+		lastModified := resp.Header.Get("Last-Modified")
+		if lastModified == "" && !opts.ifModifiedSince.IsZero() {
+			lastModified = opts.ifModifiedSince.UTC().Format(http.TimeFormat)
+		}
+		tflog.Debug(ctx, "File not modified, keeping the existing file", map[string]any{"etag": etag})
+
+		result := &downloadResult{
+			fileChecksums: checksums,
+			status:        resp.StatusCode,
+			headers:       responseHeaders(resp),
+			trailers:      map[string]string{},
+			etag:          etag,
+			lastModified:  lastModified,
+			path:          opts.path,
+			timeline:      timeline,
+			notModified:   true,
+		}
+		if err := opts.verifyContent(opts.path, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	if opts.resume {
+		return downloadResumable(ctx, opts, resp, timeline)
+	}
+
+	body, size := io.Reader(resp.Body), resp.ContentLength
+	received := int64(-1)
+
+	// raw counts the bytes received when the body is decompressed, as they
+	// no longer match what is written.
+	var raw *countingReader
+	if encoding := opts.contentEncoding(resp); encoding != "" {
+		raw = &countingReader{r: resp.Body}
+		body, err = newDecompressor(encoding, raw)
+		if err != nil {
+			return nil, err
+		}
+		size = -1
+	}
+
+	if opts.maxSize != nil && size > *opts.maxSize && !opts.transformsText() {
+		return nil, newFileTooLargeError(*opts.maxSize)
+	}
+
+	if opts.transformsText() && !opts.pagination.enabled() {
+		// The whole template is needed before anything can be rendered, and
+		// rendering before creating the file keeps it intact on errors.
+		text, err := readAllLimited(body, opts.maxSize)
+		if err != nil {
+			return nil, err
+		}
+		received = int64(len(text))
+
+		if opts.forceText || isTextContent(resp.Header.Get("Content-Type"), text) {
+			if opts.templateVars != nil {
+				text, err = renderTemplate(opts.url, text, opts.templateVars)
+				if err != nil {
+					return nil, err
+				}
+			}
+			text = normalizeLineEndings(text, opts.lineEndings)
+		}
+		body, size = bytes.NewReader(text), int64(len(text))
+	}
+
+	path := opts.path
+	if info, err := os.Stat(path); opts.serverFilename && err == nil && info.IsDir() {
+		name, err := serverFilename(resp, opts.url)
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(path, name)
+	}
+	resolvedPath := path
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, opts.dirPerm()); err != nil {
+		return nil, err
+	}
+
+	if opts.resolveSymlinks {
+		path, err = resolveParentSymlinks(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.failIfExists {
+		for _, p := range opts.extraPaths {
+			if _, err := os.Lstat(p); err == nil {
+				return nil, fmt.Errorf("%s already exists and fail_if_exists is set", p)
+			}
+		}
+		if err := claimOutputFile(path); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				os.Remove(path)
+			}
+		}()
+	}
+
+	// The content is written to temporary files that only replace the
+	// targets once it has been received completely, so a failed download
+	// leaves the files of an earlier one untouched.
+	var out *fanOutFiles
+	out, err = newFanOutFiles(append([]string{path}, opts.extraPaths...), opts.filePerm(), opts.dirPerm())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			err = out.commit()
+		} else {
+			out.abort()
+		}
+	}()
+
+	var dst io.Writer = out
+	if opts.maxSize != nil {
+		dst = &limitedWriter{w: dst, limit: *opts.maxSize}
+	}
+
+	if opts.pagination.enabled() {
+		result, err := downloadPages(ctx, limiter, opts, resp, release, dst, &timeline)
+		if err != nil {
+			return nil, err
+		}
+		result.path = resolvedPath
+		if err := opts.verifyContent(out.name(), result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	n, checksums, err := copyAndHash(dst, body, size, opts.hashChunkSize, opts.checksumAlgorithms...)
+	if err != nil {
+		return nil, err
+	}
+	if raw != nil {
+		received = raw.n
+	}
+	if received >= 0 {
+		n = received
+	}
+	tflog.Debug(ctx, "Downloaded file", map[string]any{"bytes": n, "sha256": checksums.sha256Hex})
+
+	result := &downloadResult{
+		fileChecksums: checksums,
+		status:        resp.StatusCode,
+		headers:       responseHeaders(resp),
+		trailers:      responseTrailers(resp),
+		pagesFetched:  1,
+		bytesReceived: n,
+		etag:          resp.Header.Get("ETag"),
+		lastModified:  resp.Header.Get("Last-Modified"),
+		path:          resolvedPath,
+		timeline:      timeline,
+	}
+	if resp.ContentLength >= 0 {
+		verified := resp.ContentLength == n
+		result.contentLengthVerified = &verified
+	}
+	if err := opts.verifyContent(out.name(), result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// verifyContent runs opts.verify, if set, on the content at path.
+func (o downloadOptions) verifyContent(path string, result *downloadResult) error {
+	if o.verify == nil {
+		return nil
+	}
+	return o.verify(path, result)
+}
+
+// resumePartPath returns the path of the partial file that a resumable
+// download of path is written to.
+func resumePartPath(path string) string {
+	return path + ".part"
+}
+
+// downloadResumable writes resp to the partial file of opts.path, appending
+// to it if the server answered the range request with 206 Partial Content
+// and replacing it otherwise, and renames it to opts.path once the body has
+// been received completely. If receiving the body fails, the partial file is
+// kept for the next download to resume.
+func downloadResumable(ctx context.Context, opts downloadOptions, resp *http.Response, timeline []requestAttempt) (*downloadResult, error) {
+	part := resumePartPath(opts.path)
+
+	var offset int64
+	if resp.StatusCode == http.StatusPartialContent && opts.rangeStart > 0 {
+		start, err := contentRangeStart(resp.Header.Get("Content-Range"))
+		if err != nil || start != opts.rangeStart {
+			os.Remove(part)
+			return nil, fmt.Errorf("the server answered the request for the bytes from %d with the Content-Range %q; the partial download was removed", opts.rangeStart, resp.Header.Get("Content-Range"))
+		}
+		offset = start
+	} else if opts.rangeStart > 0 {
+		tflog.Debug(ctx, "Server ignored the range request, downloading the whole file")
+	}
+
+	size := int64(-1)
+	if resp.ContentLength >= 0 {
+		size = offset + resp.ContentLength
+	}
+	if opts.maxSize != nil && size > *opts.maxSize {
+		os.Remove(part)
+		return nil, newFileTooLargeError(*opts.maxSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(part), opts.dirPerm()); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(part, os.O_RDWR|os.O_CREATE, opts.filePerm())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := f.Truncate(offset); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// The checksums cover the whole file, so the bytes received before are
+	// hashed again, but only the new ones are written.
+	src := io.MultiReader(io.NewSectionReader(f, 0, offset), resp.Body)
+	var dst io.Writer = &skipWriter{w: f, skip: offset}
+	if opts.maxSize != nil {
+		dst = &limitedWriter{w: dst, limit: *opts.maxSize}
+	}
+
+	n, checksums, err := copyAndHash(dst, src, size, opts.hashChunkSize, opts.checksumAlgorithms...)
+	if err != nil {
+		return nil, fmt.Errorf("%w; the partial download is kept in %s for the next attempt to resume", err, part)
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	received := n - offset
+	tflog.Debug(ctx, "Downloaded file", map[string]any{"bytes": received, "resumed_at": offset, "sha256": checksums.sha256Hex})
+
+	result := &downloadResult{
+		fileChecksums: checksums,
+		status:        resp.StatusCode,
+		headers:       responseHeaders(resp),
+		trailers:      responseTrailers(resp),
+		pagesFetched:  1,
+		bytesReceived: received,
+		etag:          resp.Header.Get("ETag"),
+		lastModified:  resp.Header.Get("Last-Modified"),
+		path:          opts.path,
+		timeline:      timeline,
+	}
+	if resp.ContentLength >= 0 {
+		verified := resp.ContentLength == received
+		result.contentLengthVerified = &verified
+	}
+
+	// Content that fails verification is not worth resuming.
+	if err := opts.verifyContent(part, result); err != nil {
+		os.Remove(part)
+		return nil, err
+	}
+	if err := os.Chmod(part, opts.filePerm()); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(part, opts.path); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range
+// header such as "bytes 100-199/200".
+func contentRangeStart(contentRange string) (int64, error) {
+	spec, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, fmt.Errorf("unsupported Content-Range %q", contentRange)
+	}
+	start, _, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, fmt.Errorf("invalid Content-Range %q", contentRange)
+	}
+	return strconv.ParseInt(start, 10, 64)
+}
+
+// skipWriter discards the first skip bytes written to it and passes the rest
+// on to w.
+type skipWriter struct {
+	w    io.Writer
+	skip int64
+}
+
+func (s *skipWriter) Write(p []byte) (int, error) {
+	skipped := min(s.skip, int64(len(p)))
+	s.skip -= skipped
+	if int(skipped) == len(p) {
+		return len(p), nil
+	}
+	n, err := s.w.Write(p[skipped:])
+	return int(skipped) + n, err
+}
+
+// transformsText reports whether text responses are changed before they are
+// written.
+func (o downloadOptions) transformsText() bool {
+	return o.templateVars != nil || (o.lineEndings != "" && o.lineEndings != lineEndingsPreserve)
+}
+
+// contentEncoding returns the encoding the body of resp is decompressed
+// from, or "" if it is written as received.
+func (o downloadOptions) contentEncoding(resp *http.Response) string {
+	switch o.decompress {
+	case decompressGzip:
+		return "gzip"
+	case decompressAuto:
+		return strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	default:
+		return ""
+	}
+}
+
+// newDecompressor returns a reader decompressing r, which is encoded as
+// named by a Content-Encoding header.
+func newDecompressor(encoding string, r io.Reader) (io.Reader, error) {
+	switch encoding {
+	case "identity":
+		return r, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(r)
+	case "deflate":
+		// HTTP's deflate is the zlib format rather than raw DEFLATE.
+		return zlib.NewReader(r)
+	default:
+		return nil, fmt.Errorf("unsupported Content-Encoding %q: only gzip and deflate can be decompressed", encoding)
+	}
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// throttledReader reads from r at no more than rate bytes per second on
+// average, allowing a burst of up to one second's worth of bytes.
+type throttledReader struct {
+	ctx   context.Context
+	r     io.ReadCloser
+	rate  int64
+	start time.Time
+	n     int64
+}
+
+func newThrottledReader(ctx context.Context, r io.ReadCloser, rate int64) *throttledReader {
+	return &throttledReader{ctx: ctx, r: r, rate: rate, start: time.Now()}
+}
+
+func (t *throttledReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > t.rate {
+		p = p[:t.rate]
+	}
+	n, err := t.r.Read(p)
+	t.n += int64(n)
+
+	// Wait until reading t.n bytes took as long as the rate allows.
+	due := t.start.Add(time.Duration(float64(t.n) / float64(t.rate) * float64(time.Second)))
+	if wait := time.Until(due); wait > 0 {
+		if err := sleepContext(t.ctx, wait); err != nil {
+			return n, err
+		}
+	}
+	return n, err
+}
+
+func (t *throttledReader) Close() error {
+	return t.r.Close()
+}
+
+// filePerm returns the permissions of the written file.
+func (o downloadOptions) filePerm() os.FileMode {
+	if o.fileMode == 0 {
+		return 0o644
+	}
+	return o.fileMode
+}
+
+// dirPerm returns the permissions of directories created for the file.
+func (o downloadOptions) dirPerm() os.FileMode {
+	if o.dirMode == 0 {
+		return 0o755
+	}
+	return o.dirMode
+}
+
+// conditionalOnExisting makes the request conditional on the file already
+// at o.path, if any: it is kept when the server answers 304 Not Modified to
+// either the quoted SHA256 of its content as If-None-Match or its
+// modification time as If-Modified-Since.
+func (o *downloadOptions) conditionalOnExisting() {
+	info, err := os.Stat(o.path)
+	if err != nil || !info.Mode().IsRegular() {
+		return
+	}
+	existing, err := hashFile(o.path)
+	if err != nil {
+		return
+	}
+	o.ifNoneMatch = strconv.Quote(existing.sha256Hex)
+	o.ifModifiedSince = info.ModTime()
+}
+
+// normalizeLineEndings converts every line ending in text to LF or CRLF as
+// selected by mode. Other modes return text unchanged.
+func normalizeLineEndings(text []byte, mode string) []byte {
+	switch mode {
+	case lineEndingsLF:
+		return bytes.ReplaceAll(text, []byte("\r\n"), []byte("\n"))
+	case lineEndingsCRLF:
+		lf := bytes.ReplaceAll(text, []byte("\r\n"), []byte("\n"))
+		return bytes.ReplaceAll(lf, []byte("\n"), []byte("\r\n"))
+	default:
+		return text
+	}
+}
+
+// isTextContent reports whether a response body is text, based on its
+// Content-Type or, if the server did not send one, on the content itself.
+func isTextContent(contentType string, body []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"),
+		strings.HasSuffix(mediaType, "+yaml"):
+		return true
+	}
+
+	switch mediaType {
+	case "application/json", "application/xml", "application/yaml", "application/x-yaml",
+		"application/javascript", "application/toml", "application/x-sh":
+		return true
+	}
+
+	return false
+}
+
+// templateFuncs are the functions available to templates in addition to
+// the builtins of text/template.
+var templateFuncs = template.FuncMap{
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"quote":      strconv.Quote,
+	"filename":   path.Base,
+	"dirname":    path.Dir,
+	"default": func(fallback, s string) string {
+		if s == "" {
+			return fallback
+		}
+		return s
+	},
+}
+
+// renderTemplate executes text as a Go template with vars as its data and
+// templateFuncs as its functions. Referencing a variable that is not set is
+// an error. Errors include the template name and line.
+func renderTemplate(name string, text []byte, vars map[string]string) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(templateFuncs).Parse(string(text))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// newFileTooLargeError reports a download exceeding maxSize bytes.
+func newFileTooLargeError(maxSize int64) error {
+	return fmt.Errorf("the download is larger than max_size_bytes (%d)", maxSize)
+}
+
+// limitedWriter passes writes on to w until more than limit bytes would
+// have been written in total, which fails the write.
+type limitedWriter struct {
+	w       io.Writer
+	limit   int64
+	written int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.written+int64(len(p)) > l.limit {
+		return 0, newFileTooLargeError(l.limit)
+	}
+	n, err := l.w.Write(p)
+	l.written += int64(n)
+	return n, err
+}
+
+// readAllLimited reads r to EOF like io.ReadAll, but fails once more than
+// maxSize bytes were read if maxSize is set.
+func readAllLimited(r io.Reader, maxSize *int64) ([]byte, error) {
+	if maxSize == nil {
+		return io.ReadAll(r)
+	}
+	raw, err := io.ReadAll(io.LimitReader(r, *maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > *maxSize {
+		return nil, newFileTooLargeError(*maxSize)
+	}
+	return raw, nil
+}
+
+// claimOutputFile creates an empty file at path and fails if the file
+// already exists, which is checked atomically by the operating system. The
+// download then replaces the empty file.
+func claimOutputFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if os.IsExist(err) {
+		return fmt.Errorf("%s already exists and fail_if_exists is set", path)
+	}
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// requestAttempt is an entry of the request timeline of a download.
+type requestAttempt struct {
+	// attempt is the 1-based attempt number for the requested URL.
+	attempt int
+
+	// status is the HTTP status code, or 0 if no response was received.
+	status int
+
+	// delay is the time waited before the attempt was sent.
+	delay time.Duration
+}
+
+// headersResult is the outcome of a request whose body was discarded.
+type headersResult struct {
+	status  int
+	headers map[string]string
+
+	// contentLength is the number of body bytes received.
+	contentLength int64
+}
+
+// fetchHeaders sends a GET request for opts.url and drains the body without
+// storing it, so the connection can be reused.
+func fetchHeaders(ctx context.Context, limiter *hostLimiter, opts downloadOptions) (*headersResult, error) {
+	opts.method = http.MethodGet
+	resp, release, err := sendRequest(ctx, limiter, opts, opts.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer resp.Body.Close()
+
+	n, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headersResult{
+		status:        resp.StatusCode,
+		headers:       responseHeaders(resp),
+		contentLength: n,
+	}, nil
+}
+
+// remoteMetadata describes the remote file as reported by a HEAD request.
+type remoteMetadata struct {
+	etag         string
+	lastModified string
+
+	// contentLength is -1 if the server did not send a Content-Length.
+	contentLength int64
+}
+
+// headRemote sends a HEAD request for opts.url, to learn whether the remote
+// file changed without downloading it.
+func headRemote(ctx context.Context, limiter *hostLimiter, opts downloadOptions) (*remoteMetadata, error) {
+	opts.method = http.MethodHead
+	opts.body = ""
+	opts.ifNoneMatch = ""
+	opts.ifModifiedSince = time.Time{}
+	opts.trailers = nil
+
+	resp, release, err := sendRequest(ctx, limiter, opts, opts.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	defer resp.Body.Close()
+
+	if !opts.statusExpected(resp.StatusCode) {
+		return nil, errors.New("unexpected HEAD response: " + resp.Status)
+	}
+
+	return &remoteMetadata{
+		etag:          resp.Header.Get("ETag"),
+		lastModified:  resp.Header.Get("Last-Modified"),
+		contentLength: resp.ContentLength,
+	}, nil
+}
+
+// sendRequest sends a request for rawURL built from opts and fails unless the
+// server responds with an expected status, 304 Not Modified to a conditional request or,
+// if redirects are disabled, a redirect. Connection errors and 5xx responses
+// are retried up to opts.retryMax times with exponential backoff; other
+// responses are never retried.
+// Each attempt is appended to timeline unless it is nil. The returned release
+// func frees the host limiter slot and must be called once the body has been
+// consumed.
+func sendRequest(ctx context.Context, limiter *hostLimiter, opts downloadOptions, rawURL string, timeline *[]requestAttempt) (*http.Response, func(), error) {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		req     *http.Request
+		resp    *http.Response
+		release func()
+		delay   time.Duration
+	)
+	for attempt := 1; ; attempt++ {
+		if delay > 0 {
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		req, err = newRequest(ctx, opts, rawURL)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		release, err = limiter.acquire(ctx, req.URL.Host)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resp, err = client.Do(req)
+		if timeline != nil {
+			entry := requestAttempt{attempt: attempt, delay: delay}
+			if resp != nil {
+				entry.status = resp.StatusCode
+			}
+			*timeline = append(*timeline, entry)
+		}
+
+		transient := (err != nil && !errors.Is(err, errTooManyRedirects)) || (err == nil && resp.StatusCode >= http.StatusInternalServerError)
+		if !transient || attempt > opts.retryMax || ctx.Err() != nil {
+			break
+		}
+
+		if err == nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		release()
+
+		delay = opts.retryDelay(attempt)
+		tflog.Debug(ctx, "Retrying request", map[string]any{"attempt": attempt + 1, "delay": delay.String()})
+	}
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+
+	notModified := resp.StatusCode == http.StatusNotModified && (opts.ifNoneMatch != "" || !opts.ifModifiedSince.IsZero())
+	redirect := isRedirect(resp.StatusCode) && opts.disableRedirects
+	partial := (resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable) && opts.rangeStart > 0
+	if !opts.statusExpected(resp.StatusCode) && !notModified && !redirect && !partial {
+		resp.Body.Close()
+		release()
+		if resp.StatusCode == http.StatusRequestHeaderFieldsTooLarge {
+			return nil, nil, newHeadersTooLargeError(req)
+		}
+		return nil, nil, errors.New("failed to download file: " + resp.Status)
+	}
+
+	if opts.maxBytesPerSecond > 0 {
+		resp.Body = newThrottledReader(ctx, resp.Body, opts.maxBytesPerSecond)
+	}
+	return resp, release, nil
+}
+
+// statusExpected reports whether status is one of opts.expectedStatusCodes.
+func (opts downloadOptions) statusExpected(status int) bool {
+	if len(opts.expectedStatusCodes) == 0 {
+		return status == http.StatusOK
+	}
+	return slices.Contains(opts.expectedStatusCodes, status)
+}
+
+// newRequest builds the request for rawURL, which is aborted when ctx is
+// done. A new request is needed for every attempt, as sending one consumes
+// its body.
+func newRequest(ctx context.Context, opts downloadOptions, rawURL string) (*http.Request, error) {
+	var body io.Reader
+	if opts.body != "" {
+		body = strings.NewReader(opts.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, opts.method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.body != "" {
+		contentType := opts.bodyContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	if opts.userAgent != "" {
+		req.Header.Set("User-Agent", opts.userAgent)
+	}
+	if opts.rangeStart > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", opts.rangeStart))
+	}
+	headers, err := expandHeaders(opts.headers)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if opts.basicAuth != nil {
+		req.SetBasicAuth(opts.basicAuth.username, opts.basicAuth.password)
+	} else if opts.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.bearerToken)
+	}
+	if opts.ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", opts.ifNoneMatch)
+	}
+	if !opts.ifModifiedSince.IsZero() {
+		req.Header.Set("If-Modified-Since", opts.ifModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	if opts.decompress == decompressAuto && req.Header.Get("Accept-Encoding") == "" {
+		// Asking for an encoding stops net/http from decoding gzip
+		// itself, so deflate is handled the same way.
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	}
+
+	if len(opts.trailers) > 0 {
+		// Trailers are only sent with chunked bodies, so send an empty one
+		// if there is no request body.
+		req.Trailer = make(http.Header, len(opts.trailers))
+		for k, v := range opts.trailers {
+			req.Trailer.Set(k, v)
+		}
+		if req.Body == nil {
+			req.Body = http.NoBody
+		}
+		req.ContentLength = -1
+		req.TransferEncoding = []string{"chunked"}
+	}
+
+	return req, nil
+}
+
+// expandHeaders returns a copy of headers with the environment variable
+// references in their values expanded.
+func expandHeaders(headers map[string]string) (map[string]string, error) {
+	expanded := make(map[string]string, len(headers))
+	for k, v := range headers {
+		v, err := expandEnvReferences(v)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", k, err)
+		}
+		expanded[k] = v
+	}
+	return expanded, nil
+}
+
+// expandEnvReferences replaces the references ${env:NAME} in the header
+// value s with the value of the environment variable NAME, so that
+// credentials can stay out of the configuration and the state. A reference
+// written ${env:NAME:-default} expands to default when NAME is unset or
+// empty; any other reference to an unset variable is an error. Errors name
+// the variable but never include values.
+func expandEnvReferences(s string) (string, error) {
+	const prefix = "${env:"
+	var b strings.Builder
+	for {
+		start := strings.Index(s, prefix)
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		b.WriteString(s[:start])
+		s = s[start+len(prefix):]
+
+		end := strings.IndexByte(s, '}')
+		if end < 0 {
+			return "", errors.New("unterminated ${env:...} reference")
+		}
+		name, fallback, hasFallback := strings.Cut(s[:end], ":-")
+		s = s[end+1:]
+		if !validEnvName(name) {
+			return "", fmt.Errorf("%q in an ${env:...} reference is not a valid environment variable name", name)
+		}
+
+		value, ok := os.LookupEnv(name)
+		switch {
+		case hasFallback && value == "":
+			value = fallback
+		case !ok:
+			return "", fmt.Errorf("environment variable %s is not set; set it or give a default with ${env:%s:-default}", name, name)
+		}
+		b.WriteString(value)
+	}
+}
+
+// validEnvName reports whether name is a portable environment variable
+// name: letters, digits and underscores, not starting with a digit.
+func validEnvName(name string) bool {
+	for i, r := range name {
+		if r != '_' && !('a' <= r && r <= 'z') && !('A' <= r && r <= 'Z') && (i == 0 || !('0' <= r && r <= '9')) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// retryDelay returns the time to wait after the given failed attempt. It
+// starts at retryWait and doubles with every attempt.
+func (o downloadOptions) retryDelay(attempt int) time.Duration {
+	wait := o.retryWait
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+	return wait << (attempt - 1)
+}
+
+// headersTooLargeError is returned when the server rejects a request with 431
+// Request Header Fields Too Large. It describes the headers that were sent,
+// without their values, so users can tell which ones to trim.
+type headersTooLargeError struct {
+	count   int
+	size    int
+	largest string
+}
+
+func newHeadersTooLargeError(req *http.Request) *headersTooLargeError {
+	e := &headersTooLargeError{}
+	largestSize := 0
+	for name, values := range req.Header {
+		for _, value := range values {
+			// "Name: value\r\n" as sent on the wire.
+			size := len(name) + len(value) + 4
+			e.count++
+			e.size += size
+			if size > largestSize {
+				e.largest, largestSize = name, size
+			}
+		}
+	}
+	return e
+}
+
+func (e *headersTooLargeError) Error() string {
+	msg := fmt.Sprintf("the server rejected the request with 431 Request Header Fields Too Large: %d headers totalling %d bytes were sent", e.count, e.size)
+	if e.largest != "" {
+		msg += fmt.Sprintf(", the largest being %s", e.largest)
+	}
+	return msg
+}
+
+// isRedirect reports whether status is a redirect carrying a Location.
+func isRedirect(status int) bool {
+	switch status {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// checksumFileMaxBytes bounds the size of a checksum file, which lists a
+// line per published file.
+const checksumFileMaxBytes = 1 << 20
+
+// fetchSHA256File downloads the checksum file at checksumURL and returns the
+// SHA256 checksum it lists for the file downloaded from opts.url to
+// opts.path. The request reuses the connection settings of opts and follows
+// redirects even when opts does not. The headers and credentials of opts
+// are only sent when checksumURL has the same scheme and host as opts.url.
+func fetchSHA256File(ctx context.Context, opts downloadOptions, checksumURL string) (string, error) {
+	names := []string{filepath.Base(opts.path)}
+	if u, err := url.Parse(opts.url); err == nil {
+		names = append([]string{path.Base(u.Path)}, names...)
+	}
+
+	checksumOpts := downloadOptions{
+		method:             http.MethodGet,
+		url:                checksumURL,
+		timeout:            opts.timeout,
+		sourceAddress:      opts.sourceAddress,
+		caCertPEM:          opts.caCertPEM,
+		caCertFile:         opts.caCertFile,
+		caCertAppend:       opts.caCertAppend,
+		insecureSkipVerify: opts.insecureSkipVerify,
+		clientCertPEM:      opts.clientCertPEM,
+		clientKeyPEM:       opts.clientKeyPEM,
+		proxyURL:           opts.proxyURL,
+		userAgent:          opts.userAgent,
+	}
+	if sameOrigin(opts.url, checksumURL) {
+		checksumOpts.headers = opts.headers
+		checksumOpts.basicAuth = opts.basicAuth
+		checksumOpts.bearerToken = opts.bearerToken
+	}
+
+	resp, err := fetchResponse(ctx, checksumOpts, checksumFileMaxBytes)
+	if err != nil {
+		return "", err
+	}
+	if resp.status != http.StatusOK {
+		return "", fmt.Errorf("failed to download checksum file %s: %d %s", redactURL(checksumURL), resp.status, http.StatusText(resp.status))
+	}
+
+	sum, err := parseSHA256File(resp.body, names...)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", redactURL(checksumURL), err)
+	}
+	return sum, nil
+}
+
+// sameOrigin reports whether the URLs a and b have the same scheme and
+// host, so that credentials meant for one may be sent to the other.
+func sameOrigin(a, b string) bool {
+	ua, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+	ub, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// errTooManyRedirects is returned when a request is redirected more often
+// than downloadOptions.maxRedirects allows.
+var errTooManyRedirects = errors.New("too many redirects")
+
+// newHTTPClient returns the client used to send the requests of a download.
+func newHTTPClient(opts downloadOptions) (*http.Client, error) {
+	client := &http.Client{Timeout: opts.timeout}
+	switch {
+	case opts.disableRedirects:
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	case opts.maxRedirects != nil:
+		limit := *opts.maxRedirects
+		client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
+			// via holds the requests made so far, the first of which was
+			// not a redirect.
+			if len(via) > limit {
+				return fmt.Errorf("%w: the limit is %d", errTooManyRedirects, limit)
+			}
+			return nil
+		}
+	}
+
+	customCA := opts.caCertPEM != "" || opts.caCertFile != ""
+	clientCert := opts.clientCertPEM != ""
+	if opts.sourceAddress == "" && !customCA && !opts.insecureSkipVerify && !clientCert && opts.proxyURL == "" {
+		return client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if opts.sourceAddress != "" {
+		ip := net.ParseIP(opts.sourceAddress)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid source address %q", opts.sourceAddress)
+		}
+
+		dialer := &net.Dialer{
+			LocalAddr: &net.TCPAddr{IP: ip},
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	if opts.proxyURL != "" {
+		proxy, err := parseProxyURL(opts.proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if customCA || opts.insecureSkipVerify || clientCert {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: opts.insecureSkipVerify}
+	}
+	if customCA {
+		pool, err := caCertPool(opts)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	if clientCert {
+		cert, err := tls.X509KeyPair([]byte(opts.clientCertPEM), []byte(opts.clientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("loading client_cert_pem and client_key_pem: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	client.Transport = transport
+	return client, nil
+}
+
+// caCertPool returns the pool server certificates are verified against: the
+// certificates of caCertPEM and caCertFile, added to a copy of the system
+// roots if caCertAppend is set.
+func caCertPool(opts downloadOptions) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if opts.caCertAppend {
+		// SystemCertPool returns a copy, so the shared roots are not changed.
+		system, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("loading system CA certificates: %w", err)
+		}
+		pool = system
+	}
+
+	if opts.caCertPEM != "" && !pool.AppendCertsFromPEM([]byte(opts.caCertPEM)) {
+		return nil, errors.New("ca_cert_pem contains no valid PEM certificate")
+	}
+
+	if opts.caCertFile != "" {
+		data, err := os.ReadFile(opts.caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_cert_file: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("ca_cert_file %s contains no valid PEM certificate", opts.caCertFile)
+		}
+	}
+
+	return pool, nil
+}
+
+// downloadPages writes the first response and every following page to out,
+// hashing the concatenated content. The host limiter slot of each page,
+// including releaseFirst for the first one, is released once the page has
+// been copied, so a limit of one request per host does not block the next
+// page.
+func downloadPages(ctx context.Context, limiter *hostLimiter, opts downloadOptions, first *http.Response, releaseFirst func(), out io.Writer, timeline *[]requestAttempt) (*downloadResult, error) {
+	h := newFileHasher(opts.checksumAlgorithms...)
+	w := io.MultiWriter(out, h)
+
+	resp := first
+	release := releaseFirst
+	result := &downloadResult{}
+	for {
+		next, n, err := copyPage(w, resp, opts.pagination)
+		result.pagesFetched++
+		result.bytesReceived += n
+		result.trailers = responseTrailers(resp)
+		if resp != first {
+			resp.Body.Close()
+		}
+		release()
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", result.pagesFetched, err)
+		}
+
+		if next == "" {
+			break
+		}
+		if result.pagesFetched >= opts.pagination.maxPages {
+			return nil, fmt.Errorf("pagination did not finish within %d pages", opts.pagination.maxPages)
+		}
+
+		// The next link may be relative to the page it was found on.
+		nextURL, err := resp.Request.URL.Parse(next)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: invalid next page URL %q: %w", result.pagesFetched, next, err)
+		}
+
+		resp, release, err = sendRequest(ctx, limiter, opts, nextURL.String(), timeline)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", result.pagesFetched+1, err)
+		}
+	}
+
+	result.fileChecksums = h.checksums()
+	result.timeline = *timeline
+
+	return result, nil
+}
+
+// copyPage copies the body of resp to w and returns the URL of the next page,
+// or "" if this is the last page, and the number of bytes copied.
+func copyPage(w io.Writer, resp *http.Response, pagination paginationOptions) (string, int64, error) {
+	if pagination.nextJSONField == "" {
+		n, err := io.Copy(w, resp.Body)
+		if err != nil {
+			return "", n, err
+		}
+		return nextPageFromHeader(resp.Header, pagination.nextHeader), n, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, err
+	}
+	if _, err := w.Write(body); err != nil {
+		return "", 0, err
+	}
+
+	next, err := nextPageFromJSON(body, pagination.nextJSONField)
+	return next, int64(len(body)), err
+}
+
+// nextPageFromHeader returns the next page URL from the named header. For
+// the standard Link header the target with rel="next" is used.
+func nextPageFromHeader(header http.Header, name string) string {
+	if !strings.EqualFold(name, "Link") {
+		return strings.TrimSpace(header.Get(name))
+	}
+
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				key, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(key, "rel") && strings.EqualFold(strings.Trim(val, `"`), "next") {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// nextPageFromJSON returns the string at the dot-separated field path in the
+// JSON body. A missing or null field marks the last page.
+func nextPageFromJSON(body []byte, field string) (string, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("invalid JSON page: %w", err)
+	}
+
+	for _, key := range strings.Split(field, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return "", nil
+		}
+		v = obj[key]
+	}
+
+	switch next := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return next, nil
+	default:
+		return "", fmt.Errorf("next page field %q is not a string", field)
+	}
+}
+
+// serverFilename returns the name the server gives the file of resp: the
+// filename parameter of its Content-Disposition header, or else the last
+// segment of the path of rawURL, like curl -OJ. Only the base name is kept,
+// so the file cannot be written outside of the directory.
+func serverFilename(resp *http.Response, rawURL string) (string, error) {
+	var name string
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		name = params["filename"]
+	}
+	if name == "" {
+		if u, err := url.Parse(rawURL); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+
+	// Treat backslashes as separators too, for names meant for Windows.
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "", errors.New("cannot derive a file name from the Content-Disposition header or the URL; set filename to a file instead of a directory")
+	}
+	return name, nil
+}
+
+// withQueryParameters returns rawURL with params added to its query string.
+// Parameters already in rawURL are kept unless params sets the same name.
+func withQueryParameters(rawURL string, params map[string]string) string {
+	if len(params) == 0 {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// Left for the request to report.
+		return rawURL
+	}
+	query := u.Query()
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// sourceFingerprint returns a stable identifier for the logical source of a
+// download: the SHA256 of the method, the normalized URL and, for requests
+// with a body, the SHA256 of the body and its content type. URLs that only
+// differ in the case of the scheme or host, a default port, the order of
+// query parameters or the fragment share a fingerprint.
+func sourceFingerprint(method, rawURL, body, bodyContentType string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host = net.JoinHostPort(host, port)
+	}
+	u.Host = host
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.RawQuery = u.Query().Encode()
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	source := strings.ToUpper(method) + "\n" + u.String()
+	if body != "" {
+		bodySum := sha256.Sum256([]byte(body))
+		source += "\n" + bodyContentType + "\n" + hex.EncodeToString(bodySum[:])
+	}
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// responseHeaders returns the headers of resp, joining multiple values of
+// the same header with ", " as RFC 9110 allows.
+func responseHeaders(resp *http.Response) map[string]string {
+	headers := make(map[string]string, len(resp.Header))
+	for k, v := range resp.Header {
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
+
+func responseTrailers(resp *http.Response) map[string]string {
+	trailers := make(map[string]string, len(resp.Trailer))
+	for k := range resp.Trailer {
+		trailers[k] = resp.Trailer.Get(k)
+	}
+	return trailers
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultTokenRenewBefore is how long before the token expires it is
+// renewed when renew_before is not set.
+const defaultTokenRenewBefore = time.Minute
+
+// httpTokenRenewalKey is the private data key of the request that renews
+// the token.
+const httpTokenRenewalKey = "renewal"
+
+var _ ephemeral.EphemeralResourceWithRenew = (*httpTokenEphemeralResource)(nil)
+
+type httpTokenEphemeralResource struct{}
+
+func NewHTTPTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &httpTokenEphemeralResource{}
+}
+
+func (e *httpTokenEphemeralResource) Metadata(_ context.Context, _ ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = "utility_http_token"
+}
+
+func (e *httpTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Ephemeral resource that fetches a short-lived secret, such as an access token, with an HTTP request whenever Terraform needs it. The response body is never stored in the plan or the state. The lifetime of the token is read from the `expiry_header` of the response, or else from the `expires_in` member of a JSON body, as sent by OAuth 2.0 token endpoints. With `renew_url`, Terraform renews the token before it expires during long operations.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "URL to request the token from.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method to use for the requests (default: GET). Only 'GET' and 'POST' are allowed.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPost),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in the requests. Values can reference environment variables as `$${env:NAME}` or `$${env:NAME:-default}`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"request_body": schema.StringAttribute{
+				Description: "Body to send with the request, such as the form of an OAuth 2.0 client credentials grant together with a `Content-Type` header.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"response_body_max_bytes": schema.Int64Attribute{
+				Description: "Largest response body accepted, in bytes (default: 1048576).",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"expiry_header": schema.StringAttribute{
+				Description: "Response header holding the lifetime of the token, either in seconds or as an HTTP date. Fails if the response does not have the header. When not set, the `expires_in` member of a JSON body is used, if any.",
+				Optional:    true,
+			},
+			"renew_url": schema.StringAttribute{
+				Description: "URL that extends the lifetime of the token, requested with the same method, headers and body shortly before the token expires. The new lifetime is read from its response like from the first one. Without it, the token is not renewed.",
+				Optional:    true,
+			},
+			"renew_before": schema.StringAttribute{
+				Description: "How long before the token expires it is renewed, as a Go duration such as \"5m\" (default: \"1m\"). The token is renewed after half of its lifetime at the earliest.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "Body of the response.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "HTTP status code of the response.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Time the token expires, in RFC 3339 format. Null if the response does not tell.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+type httpTokenEphemeralResourceModel struct {
+	URL                  types.String `tfsdk:"url"`
+	Method               types.String `tfsdk:"method"`
+	Headers              types.Map    `tfsdk:"headers"`
+	RequestBody          types.String `tfsdk:"request_body"`
+	ResponseBodyMaxBytes types.Int64  `tfsdk:"response_body_max_bytes"`
+	ExpiryHeader         types.String `tfsdk:"expiry_header"`
+	RenewURL             types.String `tfsdk:"renew_url"`
+	RenewBefore          types.String `tfsdk:"renew_before"`
+	Value                types.String `tfsdk:"value"`
+	StatusCode           types.Int64  `tfsdk:"status_code"`
+	ExpiresAt            types.String `tfsdk:"expires_at"`
+}
+
+// httpTokenRequest describes the requests for a token. It is kept in the
+// private data to renew the token, which Terraform holds in memory only.
+type httpTokenRequest struct {
+	URL          string            `json:"url"`
+	Method       string            `json:"method"`
+	Headers      map[string]string `json:"headers"`
+	Body         string            `json:"body"`
+	MaxBytes     int64             `json:"max_bytes"`
+	ExpiryHeader string            `json:"expiry_header"`
+	RenewBefore  time.Duration     `json:"renew_before"`
+}
+
+func (e *httpTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var config httpTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenReq := httpTokenRequest{
+		URL:          config.URL.ValueString(),
+		Method:       http.MethodGet,
+		Headers:      stringMapValue(config.Headers),
+		Body:         config.RequestBody.ValueString(),
+		MaxBytes:     defaultResponseBodyMaxBytes,
+		ExpiryHeader: config.ExpiryHeader.ValueString(),
+		RenewBefore:  defaultTokenRenewBefore,
+	}
+	if config.Method.ValueString() != "" {
+		tokenReq.Method = strings.ToUpper(config.Method.ValueString())
+	}
+	if !config.ResponseBodyMaxBytes.IsNull() {
+		tokenReq.MaxBytes = config.ResponseBodyMaxBytes.ValueInt64()
+	}
+	if !config.RenewBefore.IsNull() {
+		tokenReq.RenewBefore, _ = time.ParseDuration(config.RenewBefore.ValueString())
+	}
+
+	now := time.Now()
+	result, lifetime, err := tokenReq.send(ctx, now)
+	if err != nil {
+		resp.Diagnostics.AddError("Token Request Failed", err.Error())
+		return
+	}
+
+	config.Value = types.StringValue(string(result.body))
+	config.StatusCode = types.Int64Value(int64(result.status))
+	config.ExpiresAt = types.StringNull()
+	if lifetime > 0 {
+		config.ExpiresAt = types.StringValue(now.Add(lifetime).UTC().Format(time.RFC3339))
+	}
+
+	if !config.RenewURL.IsNull() && lifetime > 0 {
+		tokenReq.URL = config.RenewURL.ValueString()
+		data, err := json.Marshal(tokenReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Token Request Failed", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, httpTokenRenewalKey, data)...)
+		resp.RenewAt = tokenReq.renewAt(now, lifetime)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+func (e *httpTokenEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	data, diags := req.Private.GetKey(ctx, httpTokenRenewalKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+
+	var tokenReq httpTokenRequest
+	if err := json.Unmarshal(data, &tokenReq); err != nil {
+		resp.Diagnostics.AddError("Token Renewal Failed", err.Error())
+		return
+	}
+
+	now := time.Now()
+	_, lifetime, err := tokenReq.send(ctx, now)
+	if err != nil {
+		resp.Diagnostics.AddError("Token Renewal Failed", err.Error())
+		return
+	}
+	// Without a new lifetime, the token is not renewed again.
+	if lifetime > 0 {
+		resp.RenewAt = tokenReq.renewAt(now, lifetime)
+	}
+}
+
+// send sends the request described by r and returns the response with the
+// lifetime of the token, which is zero if the response does not tell. Any
+// status other than 2xx is an error.
+func (r httpTokenRequest) send(ctx context.Context, now time.Time) (*httpResponse, time.Duration, error) {
+	opts := downloadOptions{
+		method:  r.Method,
+		url:     r.URL,
+		headers: r.Headers,
+		body:    r.Body,
+	}
+	result, err := fetchResponse(ctx, opts, r.MaxBytes)
+	if err != nil {
+		return nil, 0, err
+	}
+	if result.status < 200 || result.status > 299 {
+		return nil, 0, fmt.Errorf("%s returned status %d", r.URL, result.status)
+	}
+
+	lifetime, err := tokenLifetime(result, r.ExpiryHeader, now)
+	if err != nil {
+		return nil, 0, err
+	}
+	return result, lifetime, nil
+}
+
+// renewAt returns when a token fetched at now and valid for lifetime is
+// renewed: RenewBefore before it expires, but after half of its lifetime at
+// the earliest.
+func (r httpTokenRequest) renewAt(now time.Time, lifetime time.Duration) time.Time {
+	return now.Add(max(lifetime-r.RenewBefore, lifetime/2))
+}
+
+// tokenLifetime returns how long the token in resp is valid according to
+// the header expiryHeader, holding seconds or an HTTP date, or else the
+// expires_in member of a JSON body. Zero means the response does not tell.
+func tokenLifetime(resp *httpResponse, expiryHeader string, now time.Time) (time.Duration, error) {
+	if expiryHeader != "" {
+		value, ok := resp.headers[http.CanonicalHeaderKey(expiryHeader)]
+		if !ok {
+			return 0, fmt.Errorf("the response has no %s header", expiryHeader)
+		}
+		if seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, nil
+		}
+		if t, err := http.ParseTime(value); err == nil {
+			return max(t.Sub(now), 0), nil
+		}
+		return 0, fmt.Errorf("the %s header %q is neither a number of seconds nor an HTTP date", expiryHeader, value)
+	}
+
+	var body struct {
+		ExpiresIn *json.Number `json:"expires_in"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(resp.body))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil || body.ExpiresIn == nil {
+		return 0, nil
+	}
+	seconds, err := body.ExpiresIn.Float64()
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("expires_in %s is not a number of seconds", body.ExpiresIn)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
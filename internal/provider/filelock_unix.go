@@ -0,0 +1,22 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package provider
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile blocks until it holds an exclusive lock on f.
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock taken by lockFile. Closing f releases it as
+// well, so errors are ignored.
+func unlockFile(f *os.File) {
+	_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
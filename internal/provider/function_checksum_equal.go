@@ -0,0 +1,109 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*checksumEqualFunction)(nil)
+
+// checksumPrefixes are the algorithm prefixes recognized by
+// normalizeChecksum, as used by OCI digests ("sha256:<hex>") and Subresource
+// Integrity hashes ("sha256-<base64>").
+var checksumPrefixes = []string{
+	"md5", "sha1", "sha224", "sha256", "sha384", "sha512",
+	"blake2b", "blake3", "crc32", "crc64",
+}
+
+type checksumEqualFunction struct{}
+
+func NewChecksumEqualFunction() function.Function {
+	return &checksumEqualFunction{}
+}
+
+func (f *checksumEqualFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "checksum_equal"
+}
+
+func (f *checksumEqualFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compare two checksums",
+		Description: "Compares two checksums after normalizing them: surrounding whitespace is trimmed, case is ignored for hex and an algorithm prefix such as `sha256:` is stripped. A value with a prefix such as `sha384-` is a Subresource Integrity hash, whose base64 encoded digest is compared to the digest of the other value. Fails if either value is not valid hex, or valid base64 after a `-` prefix.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "First checksum.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "Second checksum.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *checksumEqualFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	normalizedA, err := normalizeChecksum(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	normalizedB, err := normalizeChecksum(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, normalizedA == normalizedB))
+}
+
+// normalizeChecksum trims, lowercases and strips the algorithm prefix from a
+// hex encoded checksum, and fails if what remains is not valid hex. The
+// base64 encoded digest of an SRI hash is converted to hex.
+func normalizeChecksum(s string) (string, error) {
+	normalized := strings.TrimSpace(s)
+	sri := false
+	for _, prefix := range checksumPrefixes {
+		if len(normalized) > len(prefix) && strings.EqualFold(normalized[:len(prefix)], prefix) {
+			if sep := normalized[len(prefix)]; sep == ':' || sep == '-' {
+				normalized = normalized[len(prefix)+1:]
+				sri = sep == '-'
+				break
+			}
+		}
+	}
+
+	if normalized == "" {
+		return "", fmt.Errorf("checksum %q is empty", s)
+	}
+	if sri {
+		digest, err := base64.StdEncoding.DecodeString(normalized)
+		if err != nil {
+			return "", fmt.Errorf("checksum %q is not valid base64", s)
+		}
+		return hex.EncodeToString(digest), nil
+	}
+
+	normalized = strings.ToLower(normalized)
+	if _, err := hex.DecodeString(normalized); err != nil {
+		return "", fmt.Errorf("checksum %q is not valid hex", s)
+	}
+
+	return normalized, nil
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumEqualFunction(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "equal" {
+						value = provider::utility::checksum_equal(" SHA256:ABCDEF01 ", "abcdef01")
+					}
+					output "different" {
+						value = provider::utility::checksum_equal("abcdef01", "abcdef02")
+					}
+					output "sri" {
+						value = provider::utility::checksum_equal("sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("equal", "true"),
+					resource.TestCheckOutput("different", "false"),
+					resource.TestCheckOutput("sri", "true"),
+				),
+			},
+			{
+				Config: `
+					output "invalid" {
+						value = provider::utility::checksum_equal("abcdef01", "not-hex")
+					}`,
+				ExpectError: regexp.MustCompile(`is not valid hex`),
+			},
+		},
+	})
+}
+
+func TestNormalizeChecksum(t *testing.T) {
+	for input, want := range map[string]string{
+		"ABCDEF01":        "abcdef01",
+		"  abcdef01\n":    "abcdef01",
+		"sha256:ABCDEF01": "abcdef01",
+		"SHA512-q83vAQ==": "abcdef01",
+		"sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"blake3:abcdef01":   "abcdef01",
+		"md5:00112233":      "00112233",
+		"crc32:0d4a1185":    "0d4a1185",
+		"sha1:da39a3ee5e6b": "da39a3ee5e6b",
+	} {
+		got, err := normalizeChecksum(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "sha256:", "xyz", "abc", "sha256:xyz", "sha256-", "sha256-abc!", "sha256-abcdef0"} {
+		_, err := normalizeChecksum(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*fileChecksumFunction)(nil)
+
+// fileChecksumFunction returns one checksum of a local file. The file_sha256,
+// file_sha1 and file_md5 functions only differ in the algorithm.
+type fileChecksumFunction struct {
+	algorithm string
+}
+
+func NewFileSHA256Function() function.Function {
+	return &fileChecksumFunction{algorithm: checksumSHA256}
+}
+
+func NewFileSHA1Function() function.Function {
+	return &fileChecksumFunction{algorithm: checksumSHA1}
+}
+
+func NewFileMD5Function() function.Function {
+	return &fileChecksumFunction{algorithm: checksumMD5}
+}
+
+func (f *fileChecksumFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "file_" + f.algorithm
+}
+
+func (f *fileChecksumFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	name := strings.ToUpper(f.algorithm)
+	description := fmt.Sprintf("Returns the hex encoded %s checksum of a local file. The file is streamed while hashing, so large files are not held in memory, unlike `%s(file(path))`. The file must exist when the configuration is evaluated, so use the `sha256` attribute of the resource that writes it for files created during the apply.", name, f.algorithm)
+	if f.algorithm != checksumSHA256 {
+		description += " Prefer `file_sha256` unless a manifest still uses " + name + "."
+	}
+
+	resp.Definition = function.Definition{
+		Summary:     fmt.Sprintf("Compute the %s checksum of a file", name),
+		Description: description,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path of the file to hash.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *fileChecksumFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &path))
+	if resp.Error != nil {
+		return
+	}
+
+	checksums, err := hashFile(path, f.algorithm)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, checksums.get(f.algorithm)))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileChecksumFunctions(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(filename, []byte("hello world"), 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					output "sha256" {
+						value = provider::utility::file_sha256(%[1]q)
+					}
+					output "sha1" {
+						value = provider::utility::file_sha1(%[1]q)
+					}
+					output "md5" {
+						value = provider::utility::file_md5(%[1]q)
+					}`, filename),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("sha256", "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"),
+					resource.TestCheckOutput("sha1", "2aae6c35c94fcfb415dbe95f408b9ce91ee846ed"),
+					resource.TestCheckOutput("md5", "5eb63bbbe01eeed093cb22bb8f5acdc3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					output "missing" {
+						value = provider::utility::file_sha256(%q)
+					}`, filepath.Join(t.TempDir(), "missing.yaml")),
+				ExpectError: regexp.MustCompile(`no such file or directory`),
+			},
+		},
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*gunzipBase64Function)(nil)
+
+type gunzipBase64Function struct{}
+
+func NewGunzipBase64Function() function.Function {
+	return &gunzipBase64Function{}
+}
+
+func (f *gunzipBase64Function) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "gunzip_base64"
+}
+
+func (f *gunzipBase64Function) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decode base64 encoded gzip data",
+		Description: "Decodes a base64 encoded string and decompresses the gzip data it contains, such as the `content_base64_gzip` attribute of `utility_file_downloader`. The decompressed data must be valid UTF-8.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "input",
+				Description: "Base64 encoded gzip data.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *gunzipBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	output, err := gunzipBase64(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, output))
+}
+
+func gunzipBase64(input string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %w", err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", fmt.Errorf("invalid gzip data: %w", err)
+	}
+	defer gz.Close()
+
+	output, err := io.ReadAll(gz)
+	if err != nil {
+		return "", fmt.Errorf("invalid gzip data: %w", err)
+	}
+	if !utf8.Valid(output) {
+		return "", fmt.Errorf("decompressed data is not valid UTF-8")
+	}
+
+	return string(output), nil
+}
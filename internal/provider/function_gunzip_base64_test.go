@@ -0,0 +1,45 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGunzipBase64Function(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("region = eu-west-1\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					output "content" {
+						value = provider::utility::gunzip_base64(%q)
+					}`, encoded),
+				Check: resource.TestCheckOutput("content", "region = eu-west-1\n"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					output "content" {
+						value = provider::utility::gunzip_base64(%q)
+					}`, base64.StdEncoding.EncodeToString([]byte("plain"))),
+				ExpectError: regexp.MustCompile(`invalid gzip data`),
+			},
+		},
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*jsonMergeFunction)(nil)
+
+type jsonMergeFunction struct{}
+
+func NewJSONMergeFunction() function.Function {
+	return &jsonMergeFunction{}
+}
+
+func (f *jsonMergeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_merge"
+}
+
+func (f *jsonMergeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Deep merge two JSON documents",
+		Description: "Applies `b` to `a` as a JSON merge patch (RFC 7386) and returns the result as JSON. Objects are merged recursively, a `null` in `b` removes the member from `a`, and any other value in `b`, including an array, replaces the one in `a`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "a",
+				Description: "JSON document to merge into.",
+			},
+			function.StringParameter{
+				Name:        "b",
+				Description: "JSON merge patch applied to `a`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *jsonMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &a, &b))
+	if resp.Error != nil {
+		return
+	}
+
+	target, err := decodeJSON(a)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "a is not valid JSON: "+err.Error())
+		return
+	}
+	patch, err := decodeJSON(b)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "b is not valid JSON: "+err.Error())
+		return
+	}
+
+	merged, err := encodeJSON(mergePatch(target, patch))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, merged))
+}
+
+// mergePatch applies patch to target as described by RFC 7386. target may
+// be modified.
+func mergePatch(target, patch any) any {
+	members, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	object, ok := target.(map[string]any)
+	if !ok {
+		object = map[string]any{}
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		object[name] = mergePatch(object[name], value)
+	}
+	return object
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as json.Number so
+// that they are encoded again exactly as written.
+func decodeJSON(s string) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// encodeJSON encodes v compactly, without escaping HTML characters.
+func encodeJSON(v any) (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONMergeFunction(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "merged" {
+						value = provider::utility::json_merge(
+							jsonencode({ name = "api", limits = { cpu = "1", memory = "1Gi" }, debug = true }),
+							jsonencode({ limits = { memory = "2Gi" }, debug = null, replicas = 3 }),
+						)
+					}`,
+				Check: resource.TestCheckOutput("merged", `{"limits":{"cpu":"1","memory":"2Gi"},"name":"api","replicas":3}`),
+			},
+			{
+				Config: `
+					output "invalid" {
+						value = provider::utility::json_merge("{}", "{")
+					}`,
+				ExpectError: regexp.MustCompile(`b is not valid JSON`),
+			},
+		},
+	})
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from RFC 7386, appendix A.
+	for _, tc := range [][3]string{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	} {
+		target, err := decodeJSON(tc[0])
+		require.NoError(t, err)
+		patch, err := decodeJSON(tc[1])
+		require.NoError(t, err)
+		got, err := encodeJSON(mergePatch(target, patch))
+		require.NoError(t, err)
+		assert.Equal(t, tc[2], got, "%s merged with %s", tc[0], tc[1])
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	v, err := decodeJSON(` {"size": 1.50, "html": "<b>"} `)
+	require.NoError(t, err)
+	got, err := encodeJSON(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"html":"<b>","size":1.50}`, got)
+
+	for _, invalid := range []string{"", "{", `{"a":1} {"b":2}`, "nope"} {
+		_, err := decodeJSON(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
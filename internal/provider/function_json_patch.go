@@ -0,0 +1,370 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = (*jsonPatchFunction)(nil)
+
+type jsonPatchFunction struct{}
+
+func NewJSONPatchFunction() function.Function {
+	return &jsonPatchFunction{}
+}
+
+func (f *jsonPatchFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_patch"
+}
+
+func (f *jsonPatchFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Apply a JSON Patch to a JSON document",
+		Description: "Applies the operations of `patch`, a JSON Patch (RFC 6902), to `doc` in order and returns the result as JSON. The operations `add`, `remove`, `replace`, `move`, `copy` and `test` are supported. Fails if any operation fails, including a `test` whose value does not match.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "doc",
+				Description: "JSON document to patch.",
+			},
+			function.StringParameter{
+				Name:        "patch",
+				Description: "JSON array of patch operations, such as `[{\"op\": \"replace\", \"path\": \"/replicas\", \"value\": 3}]`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *jsonPatchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var docJSON, patchJSON string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &docJSON, &patchJSON))
+	if resp.Error != nil {
+		return
+	}
+
+	doc, err := decodeJSON(docJSON)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "doc is not valid JSON: "+err.Error())
+		return
+	}
+
+	var operations []jsonPatchOperation
+	if err := json.Unmarshal([]byte(patchJSON), &operations); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "patch is not a valid JSON Patch: "+err.Error())
+		return
+	}
+
+	doc, err = applyJSONPatch(doc, operations)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	patched, err := encodeJSON(doc)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, patched))
+}
+
+// jsonPatchOperation is an operation of a JSON Patch. Value is nil when the
+// operation has no value member, and "null" when the value is null.
+type jsonPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// applyJSONPatch applies operations to doc in order and returns the patched
+// document. doc may be modified.
+func applyJSONPatch(doc any, operations []jsonPatchOperation) (any, error) {
+	for i, op := range operations {
+		var err error
+		doc, err = op.apply(doc)
+		if err != nil {
+			if op.Path != nil {
+				return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, *op.Path, err)
+			}
+			return nil, fmt.Errorf("operation %d (%s): %w", i, op.Op, err)
+		}
+	}
+	return doc, nil
+}
+
+func (op jsonPatchOperation) apply(doc any) (any, error) {
+	if op.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parseJSONPointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		value, err := decodeJSON(string(op.Value))
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return jsonAdd(doc, path, value)
+		case "replace":
+			if doc, _, err = jsonRemove(doc, path); err != nil {
+				return nil, err
+			}
+			return jsonAdd(doc, path, value)
+		default:
+			current, err := jsonGet(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(current, value) {
+				return nil, fmt.Errorf("value is %s, not %s", mustEncodeJSON(current), mustEncodeJSON(value))
+			}
+			return doc, nil
+		}
+
+	case "remove":
+		doc, _, err = jsonRemove(doc, path)
+		return doc, err
+
+	case "move", "copy":
+		if op.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := parseJSONPointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		var value any
+		if op.Op == "move" {
+			if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+				return nil, fmt.Errorf("cannot move %s into one of its children", *op.From)
+			}
+			doc, value, err = jsonRemove(doc, from)
+		} else {
+			value, err = jsonGet(doc, from)
+			value = copyJSON(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return jsonAdd(doc, path, value)
+
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+// parseJSONPointer splits a JSON Pointer (RFC 6901) into its unescaped
+// reference tokens. The empty pointer refers to the whole document.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("path %q does not start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// jsonArrayIndex parses the reference token of an array element. end is the
+// largest index accepted.
+func jsonArrayIndex(token string, end int) (int, error) {
+	// Indexes are plain decimal numbers without leading zeros.
+	valid := token != "" && strings.Trim(token, "0123456789") == "" && (token == "0" || token[0] != '0')
+	i, err := strconv.Atoi(token)
+	if !valid || err != nil {
+		return 0, fmt.Errorf("%q is not an array index", token)
+	}
+	if i > end {
+		return 0, fmt.Errorf("array index %d is out of range", i)
+	}
+	return i, nil
+}
+
+func jsonGet(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]any:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			doc = value
+		case []any:
+			i, err := jsonArrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[i]
+		default:
+			return nil, fmt.Errorf("cannot look up %q in a scalar value", token)
+		}
+	}
+	return doc, nil
+}
+
+// jsonAdd adds value at path, which may be the end of an array as "-", and
+// returns the modified document.
+func jsonAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := jsonGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = value
+		return doc, nil
+	case []any:
+		i := len(node)
+		if token != "-" {
+			if i, err = jsonArrayIndex(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		return jsonSet(doc, path[:len(path)-1], slices.Insert(node, i, value))
+	default:
+		return nil, fmt.Errorf("cannot add %q to a scalar value", token)
+	}
+}
+
+// jsonRemove removes the value at path and returns the modified document
+// and the removed value.
+func jsonRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := jsonGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		value, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("member %q does not exist", token)
+		}
+		delete(node, token)
+		return doc, value, nil
+	case []any:
+		i, err := jsonArrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[i]
+		doc, err = jsonSet(doc, path[:len(path)-1], slices.Delete(node, i, i+1))
+		return doc, value, err
+	default:
+		return nil, nil, fmt.Errorf("cannot remove %q from a scalar value", token)
+	}
+}
+
+// jsonSet replaces the existing value at path, which is needed to store
+// arrays whose length changed.
+func jsonSet(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := jsonGet(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]any:
+		node[token] = value
+	case []any:
+		// jsonGet already checked the index.
+		i, _ := strconv.Atoi(token)
+		node[i] = value
+	}
+	return doc, nil
+}
+
+// jsonEqual compares two decoded JSON values, comparing numbers by value so
+// that 1 equals 1.0.
+func jsonEqual(a, b any) bool {
+	switch a := a.(type) {
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !jsonEqual(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		return ok && slices.EqualFunc(a, b, jsonEqual)
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, errA := a.Float64()
+		y, errB := b.Float64()
+		return errA == nil && errB == nil && x == y
+	default:
+		return a == b
+	}
+}
+
+// copyJSON returns a deep copy of a decoded JSON value.
+func copyJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = copyJSON(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = copyJSON(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// mustEncodeJSON encodes a decoded JSON value, which cannot fail, for error
+// messages.
+func mustEncodeJSON(v any) string {
+	s, _ := encodeJSON(v)
+	return s
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPatchFunction(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "patched" {
+						value = provider::utility::json_patch(
+							jsonencode({ spec = { replicas = 1, ports = [80] }, debug = true }),
+							jsonencode([
+								{ op = "replace", path = "/spec/replicas", value = 3 },
+								{ op = "add", path = "/spec/ports/-", value = 443 },
+								{ op = "remove", path = "/debug" },
+							]),
+						)
+					}`,
+				Check: resource.TestCheckOutput("patched", `{"spec":{"ports":[80,443],"replicas":3}}`),
+			},
+			{
+				Config: `
+					output "failed_test" {
+						value = provider::utility::json_patch(
+							jsonencode({ version = 1 }),
+							jsonencode([{ op = "test", path = "/version", value = 2 }]),
+						)
+					}`,
+				ExpectError: regexp.MustCompile(`operation 0 \(test /version\): value is 1, not 2`),
+			},
+			{
+				Config: `
+					output "invalid" {
+						value = provider::utility::json_patch("{", "[]")
+					}`,
+				ExpectError: regexp.MustCompile(`doc is not valid JSON`),
+			},
+		},
+	})
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	// Examples from RFC 6902, appendix A.
+	for _, tc := range []struct {
+		doc, patch, want string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"baz":"qux","foo":"bar"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`, `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`, `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{`{"baz":"qux","foo":["a",2,"c"]}`, `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2.0}]`, `{"baz":"qux","foo":["a",2,"c"]}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"child":{"grandchild":{}},"foo":"bar"}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+		{`{"foo":null}`, `[{"op":"test","path":"/foo","value":null}]`, `{"foo":null}`},
+		{`{"a":{"b":[1]}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/b/-","value":2}]`, `{"a":{"b":[1]},"c":{"b":[1,2]}}`},
+		{`{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	} {
+		got, err := applyTestJSONPatch(t, tc.doc, tc.patch)
+		require.NoError(t, err, tc.patch)
+		assert.Equal(t, tc.want, got, tc.patch)
+	}
+
+	for _, tc := range []struct {
+		doc, patch, err string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, `member "baz" does not exist`},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `member "baz" does not exist`},
+		{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, `member "baz" does not exist`},
+		{`{"foo":[1]}`, `[{"op":"add","path":"/foo/01","value":2}]`, `"01" is not an array index`},
+		{`{"foo":[1]}`, `[{"op":"remove","path":"/foo/1"}]`, `array index 1 is out of range`},
+		{`{"foo":"bar"}`, `[{"op":"test","path":"/foo","value":"baz"}]`, `value is "bar", not "baz"`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz"}]`, `missing value`},
+		{`{"foo":{"bar":1}}`, `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`, `into one of its children`},
+		{`{"foo":"bar"}`, `[{"op":"frobnicate","path":"/foo"}]`, `unsupported operation "frobnicate"`},
+		{`{"foo":"bar"}`, `[{"op":"remove","path":"foo"}]`, `does not start with /`},
+	} {
+		_, err := applyTestJSONPatch(t, tc.doc, tc.patch)
+		assert.ErrorContains(t, err, tc.err, tc.patch)
+	}
+}
+
+// applyTestJSONPatch applies patch to doc and returns the encoded result.
+func applyTestJSONPatch(t *testing.T, doc, patch string) (string, error) {
+	t.Helper()
+
+	v, err := decodeJSON(doc)
+	require.NoError(t, err)
+	var operations []jsonPatchOperation
+	require.NoError(t, json.Unmarshal([]byte(patch), &operations))
+
+	v, err = applyJSONPatch(v, operations)
+	if err != nil {
+		return "", err
+	}
+	return encodeJSON(v)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+)
+
+var _ function.Function = (*jsonPathFunction)(nil)
+
+type jsonPathFunction struct{}
+
+func NewJSONPathFunction() function.Function {
+	return &jsonPathFunction{}
+}
+
+func (f *jsonPathFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "jsonpath"
+}
+
+func (f *jsonPathFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Query a JSON document with a JSONPath expression",
+		Description: "Evaluates the JSONPath expression `expression` against `json`. An expression made only of member names and array indexes, such as `$.spec.ports[0]`, returns the value it matches. Any other expression, using wildcards, slices, unions, recursive descent or filters, returns a tuple of all the values it matches, in document order. Objects and arrays are returned as objects and tuples, and JSON `null` as a null string. Fails if nothing matches, unless `default` is given, in which case `default` is returned instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "JSON document to query.",
+			},
+			function.StringParameter{
+				Name:        "expression",
+				Description: "JSONPath expression, such as `$.items[?(@.enabled == true)].name`. The leading `$` may be omitted.",
+			},
+		},
+		VariadicParameter: function.DynamicParameter{
+			Name:        "default",
+			Description: "Value to return when nothing matches. At most one may be given.",
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *jsonPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document, expression string
+	var defaults []types.Dynamic
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &document, &expression, &defaults))
+	if resp.Error != nil {
+		return
+	}
+	if len(defaults) > 1 {
+		resp.Error = function.NewArgumentFuncError(2, "at most one default may be given")
+		return
+	}
+
+	doc, err := oj.ParseString(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "json is not valid JSON: "+err.Error())
+		return
+	}
+	expr, err := jp.ParseString(expression)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "expression is not a valid JSONPath expression: "+err.Error())
+		return
+	}
+
+	matches := expr.Get(doc)
+	if len(matches) == 0 {
+		if len(defaults) == 0 {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("expression %s matched nothing", expression))
+			return
+		}
+		resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, defaults[0]))
+		return
+	}
+
+	value, err := jsonPathResult(expr, matches)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.DynamicValue(value)))
+}
+
+// jsonPathResult returns the value matched by a singular expr, which only
+// selects members and array elements by name or index, or a tuple of all
+// matches for any other expression.
+func jsonPathResult(expr jp.Expr, matches []any) (attr.Value, error) {
+	if jsonPathSingular(expr) {
+		return valueFromJSON(matches[0])
+	}
+	return valueFromJSON(matches)
+}
+
+func jsonPathSingular(expr jp.Expr) bool {
+	for _, frag := range expr {
+		switch frag.(type) {
+		case jp.Root, jp.At, jp.Child, jp.Nth, jp.Bracket:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// valueFromJSON converts a JSON value parsed by oj to a Terraform value.
+func valueFromJSON(v any) (attr.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case string:
+		return types.StringValue(v), nil
+	case int64:
+		return types.NumberValue(new(big.Float).SetInt64(v)), nil
+	case float64:
+		// Parse the shortest text of the float again so that decimal values
+		// such as 0.1 keep their exact value.
+		n, _, err := big.ParseFloat(strconv.FormatFloat(v, 'g', -1, 64), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(n), nil
+	case json.Number:
+		// oj returns numbers that do not fit an int64 or a float64 exactly
+		// as text.
+		n, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(n), nil
+	case []any:
+		elements := make([]attr.Value, len(v))
+		elementTypes := make([]attr.Type, len(v))
+		for i, e := range v {
+			element, err := valueFromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+			elementTypes[i] = element.Type(context.Background())
+		}
+		return types.TupleValueMust(elementTypes, elements), nil
+	case map[string]any:
+		attributes := make(map[string]attr.Value, len(v))
+		attributeTypes := make(map[string]attr.Type, len(v))
+		for k, e := range v {
+			value, err := valueFromJSON(e)
+			if err != nil {
+				return nil, err
+			}
+			attributes[k] = value
+			attributeTypes[k] = value.Type(context.Background())
+		}
+		return types.ObjectValueMust(attributeTypes, attributes), nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", v)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPathFunction(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					locals {
+						release = jsonencode({
+							tag_name = "v1.4.0"
+							assets = [
+								{ name = "app_linux_amd64.zip", size = 1024 },
+								{ name = "app_linux_arm64.zip", size = 998 },
+								{ name = "app_darwin_arm64.zip", size = 1010 },
+							]
+						})
+					}
+
+					output "tag" {
+						value = provider::utility::jsonpath(local.release, "$.tag_name")
+					}
+
+					output "first_two" {
+						value = join(",", provider::utility::jsonpath(local.release, "$.assets[0:2].name"))
+					}
+
+					output "linux" {
+						value = join(",", provider::utility::jsonpath(local.release, "$.assets[?(@.name =~ /linux/ && @.size > 1000)].name"))
+					}
+
+					output "missing" {
+						value = provider::utility::jsonpath(local.release, "$.body", "none")
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("tag", "v1.4.0"),
+					resource.TestCheckOutput("first_two", "app_linux_amd64.zip,app_linux_arm64.zip"),
+					resource.TestCheckOutput("linux", "app_linux_amd64.zip"),
+					resource.TestCheckOutput("missing", "none"),
+				),
+			},
+			{
+				Config: `
+					output "no_match" {
+						value = provider::utility::jsonpath(jsonencode({ a = 1 }), "$.b")
+					}`,
+				ExpectError: regexp.MustCompile(`expression \$\.b matched nothing`),
+			},
+			{
+				Config: `
+					output "invalid" {
+						value = provider::utility::jsonpath(jsonencode({ a = 1 }), "$[")
+					}`,
+				ExpectError: regexp.MustCompile(`expression is not a valid JSONPath expression`),
+			},
+		},
+	})
+}
+
+func TestJSONPathResult(t *testing.T) {
+	doc, err := oj.ParseString(`{
+		"store": {
+			"book": [
+				{"title": "Sayings of the Century", "author": "Nigel Rees", "price": 8.95},
+				{"title": "Sword of Honour", "author": "Evelyn Waugh", "price": 12.99},
+				{"title": "Moby Dick", "author": "Herman Melville", "price": 8.99, "isbn": 12345678901234567890}
+			],
+			"bicycle": {"color": "red", "price": 19.95, "owner": null}
+		}
+	}`)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		expression string
+		want       attr.Value
+	}{
+		{`$.store.bicycle.color`, types.StringValue("red")},
+		{`store.bicycle['price']`, testJSONNumber(t, "19.95")},
+		{`$.store.bicycle.owner`, types.StringNull()},
+		{`$.store.book[-1].isbn`, testJSONNumber(t, "12345678901234567890")},
+		{`$.store.book[0]`, types.ObjectValueMust(
+			map[string]attr.Type{"title": types.StringType, "author": types.StringType, "price": types.NumberType},
+			map[string]attr.Value{"title": types.StringValue("Sayings of the Century"), "author": types.StringValue("Nigel Rees"), "price": testJSONNumber(t, "8.95")},
+		)},
+		{`$.store.book[1:].author`, testJSONStrings("Evelyn Waugh", "Herman Melville")},
+		{`$.store.book[?(@.price < 10)].title`, testJSONStrings("Sayings of the Century", "Moby Dick")},
+		{`$.store.book[?(@.isbn)].title`, testJSONStrings("Moby Dick")},
+		{`$..book[0,2].author`, testJSONStrings("Nigel Rees", "Herman Melville")},
+		{`$.store.*.color`, testJSONStrings("red")},
+	} {
+		expr, err := jp.ParseString(tc.expression)
+		require.NoError(t, err, tc.expression)
+		matches := expr.Get(doc)
+		require.NotEmpty(t, matches, tc.expression)
+
+		got, err := jsonPathResult(expr, matches)
+		require.NoError(t, err, tc.expression)
+		assert.True(t, tc.want.Equal(got), "%s: got %s, want %s", tc.expression, got, tc.want)
+	}
+}
+
+func testJSONNumber(t *testing.T, s string) types.Number {
+	n, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+	require.NoError(t, err)
+	return types.NumberValue(n)
+}
+
+func testJSONStrings(values ...string) types.Tuple {
+	elements := make([]attr.Value, len(values))
+	elementTypes := make([]attr.Type, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+		elementTypes[i] = types.StringType
+	}
+	return types.TupleValueMust(elementTypes, elements)
+}
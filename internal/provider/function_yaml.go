@@ -0,0 +1,325 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_ function.Function = (*yamlEncodeFunction)(nil)
+	_ function.Function = (*yamlDecodeFunction)(nil)
+)
+
+type yamlEncodeFunction struct{}
+
+func NewYAMLEncodeFunction() function.Function {
+	return &yamlEncodeFunction{}
+}
+
+func (f *yamlEncodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "yaml_encode"
+}
+
+func (f *yamlEncodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Encode a value as YAML",
+		Description: "Encodes any value as a YAML document indented by two spaces. Objects and maps become mappings with their keys sorted, lists, sets and tuples become sequences, and numbers are written exactly, as integers when they have no fractional part. Strings are quoted where needed to keep them strings, such as `\"true\"` or `\"1.0\"`, and multi-line strings use the literal block style. Null values are written as `null`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "value",
+				Description: "Value to encode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *yamlEncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	document, err := encodeYAML(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "value cannot be encoded as YAML: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, document))
+}
+
+type yamlDecodeFunction struct{}
+
+func NewYAMLDecodeFunction() function.Function {
+	return &yamlDecodeFunction{}
+}
+
+func (f *yamlDecodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "yaml_decode"
+}
+
+func (f *yamlDecodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Decode a YAML document",
+		Description: "Decodes a single YAML document. Mappings become objects, sequences become tuples, and scalars become strings, numbers or booleans following the YAML 1.2 core schema; timestamps and other tagged scalars are kept as the string written. Aliases are expanded, and `null` becomes a null string. Fails on malformed YAML, on mapping keys that are not scalars or are repeated, and on input holding more than one document.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "document",
+				Description: "YAML document to decode.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *yamlDecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &document))
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := decodeYAML(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "document is not valid YAML: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, types.DynamicValue(value)))
+}
+
+// encodeYAML encodes value as a YAML document indented by two spaces.
+func encodeYAML(value attr.Value) (string, error) {
+	node, err := yamlNodeFromValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// yamlNodeFromValue converts a Terraform value to a YAML node.
+func yamlNodeFromValue(value attr.Value) (*yaml.Node, error) {
+	if value.IsNull() {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	if value.IsUnknown() {
+		return nil, errors.New("the value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return yamlNodeFromValue(v.UnderlyingValue())
+	case basetypes.StringValue:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.ValueString()}
+		if strings.Contains(node.Value, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node, nil
+	case basetypes.BoolValue:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(v.ValueBool())}, nil
+	case basetypes.NumberValue:
+		n := v.ValueBigFloat()
+		if n.IsInt() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: n.Text('f', 0)}, nil
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: n.Text('g', -1)}, nil
+	case basetypes.ListValue:
+		return yamlSequence(v.Elements())
+	case basetypes.SetValue:
+		return yamlSequence(v.Elements())
+	case basetypes.TupleValue:
+		return yamlSequence(v.Elements())
+	case basetypes.MapValue:
+		return yamlMapping(v.Elements())
+	case basetypes.ObjectValue:
+		return yamlMapping(v.Attributes())
+	default:
+		return nil, fmt.Errorf("unsupported type %s", value.Type(context.Background()))
+	}
+}
+
+func yamlSequence(elements []attr.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, element := range elements {
+		child, err := yamlNodeFromValue(element)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, child)
+	}
+	return node, nil
+}
+
+func yamlMapping(elements map[string]attr.Value) (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, key := range slices.Sorted(maps.Keys(elements)) {
+		child, err := yamlNodeFromValue(elements[key])
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, child)
+	}
+	return node, nil
+}
+
+// decodeYAML decodes document, which must hold exactly one YAML document,
+// to a Terraform value.
+func decodeYAML(document string) (attr.Value, error) {
+	dec := yaml.NewDecoder(strings.NewReader(document))
+
+	var node yaml.Node
+	if err := dec.Decode(&node); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("no YAML document found")
+		}
+		return nil, err
+	}
+	var next yaml.Node
+	if err := dec.Decode(&next); err != io.EOF {
+		return nil, errors.New("more than one YAML document found")
+	}
+
+	d := yamlValueDecoder{expanding: map[*yaml.Node]bool{}}
+	return d.value(&node)
+}
+
+// maxYAMLAliasExpansions limits how many aliases a document may expand, so
+// that nested aliases cannot blow up a small document into a huge value.
+const maxYAMLAliasExpansions = 10_000
+
+// yamlValueDecoder converts decoded YAML nodes to Terraform values.
+type yamlValueDecoder struct {
+	// expanding holds the anchored nodes whose aliases are being expanded,
+	// to detect nodes that contain an alias to themselves.
+	expanding map[*yaml.Node]bool
+	aliases   int
+}
+
+func (d *yamlValueDecoder) value(node *yaml.Node) (attr.Value, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return d.value(node.Content[0])
+
+	case yaml.AliasNode:
+		if d.expanding[node.Alias] {
+			return nil, fmt.Errorf("line %d: alias *%s refers to a node containing it", node.Line, node.Value)
+		}
+		if d.aliases++; d.aliases > maxYAMLAliasExpansions {
+			return nil, fmt.Errorf("line %d: more than %d aliases expanded", node.Line, maxYAMLAliasExpansions)
+		}
+		d.expanding[node.Alias] = true
+		defer delete(d.expanding, node.Alias)
+		return d.value(node.Alias)
+
+	case yaml.SequenceNode:
+		elements := make([]attr.Value, len(node.Content))
+		elementTypes := make([]attr.Type, len(node.Content))
+		for i, child := range node.Content {
+			element, err := d.value(child)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+			elementTypes[i] = element.Type(context.Background())
+		}
+		return types.TupleValueMust(elementTypes, elements), nil
+
+	case yaml.MappingNode:
+		attributes := make(map[string]attr.Value, len(node.Content)/2)
+		attributeTypes := make(map[string]attr.Type, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: mapping keys must be scalars", key.Line)
+			}
+			if _, ok := attributes[key.Value]; ok {
+				return nil, fmt.Errorf("line %d: mapping key %q is repeated", key.Line, key.Value)
+			}
+			value, err := d.value(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			attributes[key.Value] = value
+			attributeTypes[key.Value] = value.Type(context.Background())
+		}
+		return types.ObjectValueMust(attributeTypes, attributes), nil
+
+	case yaml.ScalarNode:
+		return valueFromYAMLScalar(node)
+
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+	}
+}
+
+func valueFromYAMLScalar(node *yaml.Node) (attr.Value, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return types.StringNull(), nil
+
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, err
+		}
+		return types.BoolValue(b), nil
+
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err == nil {
+			return types.NumberValue(new(big.Float).SetInt64(i)), nil
+		}
+		var u uint64
+		if err := node.Decode(&u); err != nil {
+			return nil, err
+		}
+		return types.NumberValue(new(big.Float).SetUint64(u)), nil
+
+	case "!!float":
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return nil, err
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("line %d: %s is not a finite number", node.Line, node.Value)
+		}
+		// Parse the text again so that decimal values keep their exact
+		// value rather than the nearest float64.
+		if n, _, err := big.ParseFloat(node.Value, 10, 512, big.ToNearestEven); err == nil {
+			return types.NumberValue(n), nil
+		}
+		return types.NumberValue(big.NewFloat(f)), nil
+
+	default:
+		return types.StringValue(node.Value), nil
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLFunctions(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "encoded" {
+						value = provider::utility::yaml_encode({
+							name   = "api"
+							ports  = [80, 443]
+							limits = { cpu = "1", memory = "1Gi" }
+							debug  = true
+						})
+					}
+
+					output "decoded" {
+						value = provider::utility::yaml_decode(<<-EOT
+							name: api
+							replicas: 3
+							containers:
+							  - image: nginx:1.27
+							    ports: [80, 443]
+							EOT
+						).containers[0].ports[1]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("encoded", "debug: true\nlimits:\n  cpu: \"1\"\n  memory: 1Gi\nname: api\nports:\n  - 80\n  - 443\n"),
+					resource.TestCheckOutput("decoded", "443"),
+				),
+			},
+			{
+				Config: `
+					output "multiple" {
+						value = provider::utility::yaml_decode("a: 1\n---\nb: 2\n")
+					}`,
+				ExpectError: regexp.MustCompile(`more than one YAML document found`),
+			},
+			{
+				Config: `
+					output "invalid" {
+						value = provider::utility::yaml_decode("a: [1")
+					}`,
+				ExpectError: regexp.MustCompile(`document is not valid YAML`),
+			},
+		},
+	})
+}
+
+func TestEncodeYAML(t *testing.T) {
+	value := types.ObjectValueMust(
+		map[string]attr.Type{
+			"name":    types.StringType,
+			"version": types.StringType,
+			"script":  types.StringType,
+			"ratio":   types.NumberType,
+			"unset":   types.StringType,
+			"tags":    types.SetType{ElemType: types.StringType},
+			"env":     types.MapType{ElemType: types.StringType},
+			"steps": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"run": types.StringType,
+			}}},
+		},
+		map[string]attr.Value{
+			"name":    types.StringValue("api"),
+			"version": types.StringValue("1.0"),
+			"script":  types.StringValue("set -e\nmake\n"),
+			"ratio":   types.NumberValue(big.NewFloat(0.25)),
+			"unset":   types.StringNull(),
+			"tags":    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("true")}),
+			"env":     types.MapValueMust(types.StringType, map[string]attr.Value{"B": types.StringValue("2"), "A": types.StringValue("1")}),
+			"steps": types.ListValueMust(
+				types.ObjectType{AttrTypes: map[string]attr.Type{"run": types.StringType}},
+				[]attr.Value{types.ObjectValueMust(map[string]attr.Type{"run": types.StringType}, map[string]attr.Value{"run": types.StringValue("test")})},
+			),
+		},
+	)
+
+	got, err := encodeYAML(types.DynamicValue(value))
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		`env:`,
+		`  A: "1"`,
+		`  B: "2"`,
+		`name: api`,
+		`ratio: 0.25`,
+		`script: |`,
+		`  set -e`,
+		`  make`,
+		`steps:`,
+		`  - run: test`,
+		`tags:`,
+		`  - "true"`,
+		`unset: null`,
+		`version: "1.0"`,
+		``,
+	}, "\n"), got)
+
+	_, err = encodeYAML(types.StringUnknown())
+	assert.Error(t, err)
+}
+
+func TestDecodeYAML(t *testing.T) {
+	value, err := decodeYAML(strings.Join([]string{
+		`defaults: &defaults`,
+		`  retries: 3`,
+		`  timeout: 2.50`,
+		`services:`,
+		`  - name: api`,
+		`    settings: *defaults`,
+		`    enabled: yes`,
+		`    released: 2024-06-01`,
+		`    owner: ~`,
+		`  - name: "042"`,
+		`    enabled: false`,
+		`    port: 0x1F90`,
+	}, "\n"))
+	require.NoError(t, err)
+
+	got, err := encodeYAML(value)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{
+		`defaults:`,
+		`  retries: 3`,
+		`  timeout: 2.5`,
+		`services:`,
+		`  - enabled: yes`,
+		`    name: api`,
+		`    owner: null`,
+		`    released: "2024-06-01"`,
+		`    settings:`,
+		`      retries: 3`,
+		`      timeout: 2.5`,
+		`  - enabled: false`,
+		`    name: "042"`,
+		`    port: 8080`,
+		``,
+	}, "\n"), got)
+
+	for document, want := range map[string]string{
+		"":                   "no YAML document found",
+		"a: 1\n---\nb: 2\n":  "more than one YAML document found",
+		"a: [1":              "did not find expected ',' or ']'",
+		"? [a]\n: 1\n":       "mapping keys must be scalars",
+		"a: 1\na: 2\n":       `mapping key "a" is repeated`,
+		"a: .nan\n":          ".nan is not a finite number",
+		"a: &a [1, *a]\n":    "alias *a refers to a node containing it",
+		yamlAliasBomb(10, 6): "more than 10000 aliases expanded",
+	} {
+		_, err := decodeYAML(document)
+		assert.ErrorContains(t, err, want, document)
+	}
+}
+
+// yamlAliasBomb returns a document whose last key expands to width^depth
+// scalars through nested aliases.
+func yamlAliasBomb(width, depth int) string {
+	var b strings.Builder
+	b.WriteString("l0: &l0 x\n")
+	for i := 1; i <= depth; i++ {
+		b.WriteString("l" + strconv.Itoa(i) + ": &l" + strconv.Itoa(i) + " [")
+		b.WriteString(strings.TrimSuffix(strings.Repeat("*l"+strconv.Itoa(i-1)+", ", width), ", "))
+		b.WriteString("]\n")
+	}
+	return b.String()
+}
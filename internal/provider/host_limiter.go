@@ -0,0 +1,49 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// hostLimiter bounds the number of in-flight requests per host using one
+// semaphore per host, so a single slow host cannot starve the others.
+type hostLimiter struct {
+	limit int
+
+	mu   sync.Mutex
+	sems map[string]chan struct{}
+}
+
+func newHostLimiter(limit int) *hostLimiter {
+	return &hostLimiter{
+		limit: limit,
+		sems:  make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a slot for host is available or ctx is done. The
+// returned release func must be called once the request has finished. A nil
+// limiter or a non-positive limit never blocks.
+func (l *hostLimiter) acquire(ctx context.Context, host string) (func(), error) {
+	if l == nil || l.limit <= 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	sem, ok := l.sems[host]
+	if !ok {
+		sem = make(chan struct{}, l.limit)
+		l.sems[host] = sem
+	}
+	l.mu.Unlock()
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
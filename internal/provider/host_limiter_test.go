@@ -0,0 +1,49 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostLimiter(t *testing.T) {
+	l := newHostLimiter(1)
+
+	release, err := l.acquire(context.Background(), "a.example.com")
+	require.NoError(t, err)
+
+	// A different host has its own slot.
+	releaseB, err := l.acquire(context.Background(), "b.example.com")
+	require.NoError(t, err)
+	releaseB()
+
+	// The same host must wait until the slot is released.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = l.acquire(ctx, "a.example.com")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release, err = l.acquire(context.Background(), "a.example.com")
+	require.NoError(t, err)
+	release()
+}
+
+func TestHostLimiter_Unlimited(t *testing.T) {
+	var l *hostLimiter
+	release, err := l.acquire(context.Background(), "a.example.com")
+	require.NoError(t, err)
+	release()
+
+	l = newHostLimiter(0)
+	for range 3 {
+		_, err := l.acquire(context.Background(), "a.example.com")
+		require.NoError(t, err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// secretLogTagRegexp matches log tag keys whose values are masked because
+// they look like they hold credentials.
+var secretLogTagRegexp = regexp.MustCompile(`(?i)pass(word|wd)?|secret|token|auth|credential|key`)
+
+// withLogFields returns ctx with fields and the user supplied tags attached
+// to every tflog event. fields take precedence over tags with the same key.
+// Tags with secret-looking keys are masked, as is any occurrence of the
+// secrets in any field value or message.
+func withLogFields(ctx context.Context, fields map[string]any, tags map[string]string, secrets []string) context.Context {
+	for k, v := range tags {
+		if _, ok := fields[k]; ok {
+			continue
+		}
+		ctx = tflog.SetField(ctx, k, v)
+		if secretLogTagRegexp.MatchString(k) {
+			ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, k)
+		}
+	}
+
+	for k, v := range fields {
+		ctx = tflog.SetField(ctx, k, v)
+	}
+
+	var nonEmpty []string
+	for _, s := range secrets {
+		if s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	if len(nonEmpty) > 0 {
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, nonEmpty...)
+		ctx = tflog.MaskMessageStrings(ctx, nonEmpty...)
+	}
+
+	return ctx
+}
+
+// redactURL returns rawURL with any password and the values of query
+// parameters with secret-looking names, such as access_token, replaced, for
+// logs and diagnostics.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		if secretLogTagRegexp.MatchString(name) {
+			query[name] = []string{"xxxxx"}
+			redacted = true
+		}
+	}
+	if redacted {
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
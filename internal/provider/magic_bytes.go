@@ -0,0 +1,89 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+// magicBytes maps the file types verify_magic_bytes accepts by name to the
+// prefixes files of that type start with.
+var magicBytes = map[string][][]byte{
+	"7z":    {[]byte("7z\xbc\xaf\x27\x1c")},
+	"bzip2": {[]byte("BZh")},
+	"elf":   {[]byte("\x7fELF")},
+	"gif":   {[]byte("GIF87a"), []byte("GIF89a")},
+	"gzip":  {{0x1f, 0x8b}},
+	"jpeg":  {{0xff, 0xd8, 0xff}},
+	"pdf":   {[]byte("%PDF-")},
+	"png":   {[]byte("\x89PNG\r\n\x1a\n")},
+	"xz":    {[]byte("\xfd7zXZ\x00")},
+	// Empty zip archives consist of the end of central directory record.
+	"zip":  {[]byte("PK\x03\x04"), []byte("PK\x05\x06")},
+	"zstd": {{0x28, 0xb5, 0x2f, 0xfd}},
+}
+
+// parseMagicBytes returns the prefixes that spec, the name of a file type in
+// magicBytes or a hex-encoded prefix, accepts.
+func parseMagicBytes(spec string) ([][]byte, error) {
+	if prefixes, ok := magicBytes[strings.ToLower(spec)]; ok {
+		return prefixes, nil
+	}
+
+	prefix, err := hex.DecodeString(spec)
+	if err != nil || len(prefix) == 0 {
+		names := slices.Sorted(func(yield func(string) bool) {
+			for name := range magicBytes {
+				if !yield(name) {
+					return
+				}
+			}
+		})
+		return nil, fmt.Errorf("%q is neither hex-encoded bytes such as \"1f8b\" nor one of the file types %s", spec, strings.Join(names, ", "))
+	}
+	return [][]byte{prefix}, nil
+}
+
+// checkMagicBytes reports an error unless the file at path starts with one
+// of the prefixes spec accepts, naming what the file looks like instead,
+// e.g. an HTML error page.
+func checkMagicBytes(path, spec string) error {
+	prefixes, err := parseMagicBytes(spec)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// DetectContentType considers at most 512 bytes.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+	head = head[:n]
+
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(head, prefix) {
+			return nil
+		}
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s is empty, expected it to start with the magic bytes of %s", path, spec)
+	}
+	start := head[:min(n, 8)]
+	return fmt.Errorf("%s starts with %x, not the magic bytes of %s; the content looks like %s", path, start, spec, http.DetectContentType(head))
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMagicBytes(t *testing.T) {
+	prefixes, err := parseMagicBytes("ZIP")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("PK\x03\x04"), []byte("PK\x05\x06")}, prefixes)
+
+	prefixes, err = parseMagicBytes("1F8B08")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{0x1f, 0x8b, 0x08}}, prefixes)
+
+	for _, invalid := range []string{"", "1f8", "tarball", "0x1f8b"} {
+		_, err := parseMagicBytes(invalid)
+		assert.ErrorContains(t, err, "nor one of the file types 7z, bzip2, elf, gif, gzip, jpeg, pdf, png, xz, zip, zstd", invalid)
+	}
+}
+
+func TestCheckMagicBytes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, content, 0o644))
+		return path
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	_, err := zw.Write([]byte("payload"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	archive := write("archive.gz", gz.Bytes())
+
+	assert.NoError(t, checkMagicBytes(archive, "gzip"))
+	assert.NoError(t, checkMagicBytes(archive, "1f8b"))
+	assert.NoError(t, checkMagicBytes(write("image.png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")), "png"))
+	assert.NoError(t, checkMagicBytes(write("empty.zip", []byte("PK\x05\x06"+string(make([]byte, 18)))), "zip"))
+
+	page := write("error.html", []byte("<!DOCTYPE html><html><body>502 Bad Gateway</body></html>"))
+	err = checkMagicBytes(page, "gzip")
+	assert.EqualError(t, err, page+" starts with 3c21444f43545950, not the magic bytes of gzip; the content looks like text/html; charset=utf-8")
+
+	// A prefix longer than the file does not match.
+	assert.Error(t, checkMagicBytes(write("short", []byte{0x1f}), "gzip"))
+	assert.EqualError(t, checkMagicBytes(write("empty", nil), "pdf"), filepath.Join(dir, "empty")+" is empty, expected it to start with the magic bytes of pdf")
+}
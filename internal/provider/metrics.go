@@ -0,0 +1,75 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// downloadMetric is one line of a metrics_file.
+type downloadMetric struct {
+	Time       time.Time `json:"time"`
+	URL        string    `json:"url"`
+	Filename   string    `json:"filename,omitempty"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	DurationMS int64     `json:"duration_ms"`
+	Retries    int       `json:"retries"`
+	SHA256     string    `json:"sha256,omitempty"`
+}
+
+// newDownloadMetric describes a download that took duration.
+func newDownloadMetric(url, filename string, result *downloadResult, duration time.Duration) downloadMetric {
+	metric := downloadMetric{
+		Time:       time.Now().UTC(),
+		URL:        redactURL(url),
+		Filename:   filename,
+		Bytes:      result.bytesReceived,
+		DurationMS: duration.Milliseconds(),
+	}
+	for _, attempt := range result.timeline {
+		metric.Status = attempt.status
+		if attempt.attempt > 1 {
+			metric.Retries++
+		}
+	}
+	if result.fileChecksums != nil {
+		metric.SHA256 = result.sha256Hex
+	}
+	return metric
+}
+
+// appendMetric appends metric as a JSON line to path, creating the file and
+// its directory if needed. The file is locked while writing, so concurrent
+// writers, including other provider processes, never interleave lines.
+func appendMetric(path string, metric downloadMetric) error {
+	line, err := json.Marshal(metric)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return err
+	}
+	defer unlockFile(f)
+
+	// A single write keeps the line intact even on file systems where the
+	// lock is advisory only.
+	_, err = f.Write(line)
+	return err
+}
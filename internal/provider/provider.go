@@ -5,14 +5,22 @@ package provider
 
 import (
 	"context"
-	"crypto/sha1"
-	"crypto/sha256"
-	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func New(v string) func() provider.Provider {
@@ -23,12 +31,46 @@ func New(v string) func() provider.Provider {
 	}
 }
 
-var _ provider.Provider = (*fileDownloaderProvider)(nil)
+var (
+	_ provider.ProviderWithFunctions          = (*fileDownloaderProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*fileDownloaderProvider)(nil)
+)
 
 type fileDownloaderProvider struct {
 	version string
 }
 
+type fileDownloaderProviderModel struct {
+	MaxConcurrentPerHost types.Int64  `tfsdk:"max_concurrent_per_host"`
+	DefaultHeaders       types.Map    `tfsdk:"default_headers"`
+	BaseURL              types.String `tfsdk:"base_url"`
+	RequestTimeout       types.String `tfsdk:"request_timeout"`
+	RetryMax             types.Int64  `tfsdk:"retry_max"`
+	RetryWait            types.String `tfsdk:"retry_wait"`
+}
+
+// providerData is handed to resources and data sources through Configure.
+type providerData struct {
+	hostLimiter *hostLimiter
+
+	// defaultHeaders are sent with every download unless the resource sets
+	// a header of the same name.
+	defaultHeaders map[string]string
+
+	// baseURL, if set, is what relative download URLs are resolved against.
+	baseURL *url.URL
+
+	// userAgent is sent as User-Agent by downloads that set no other.
+	userAgent string
+
+	// requestTimeout, retryMax and retryWait are used by downloads whose
+	// resource leaves timeout, retry_max or retry_wait unset. Zero values
+	// keep the built-in defaults.
+	requestTimeout time.Duration
+	retryMax       int
+	retryWait      time.Duration
+}
+
 func (p *fileDownloaderProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "utility"
 	resp.Version = p.version
@@ -37,35 +79,212 @@ func (p *fileDownloaderProvider) Metadata(_ context.Context, _ provider.Metadata
 func (p *fileDownloaderProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: `
-The Utility provider offers various utility functions and tools for use in Terraform configurations. This provider does not require configuration.
+The Utility provider offers various utility functions and tools for use in Terraform configurations. Configuration of this provider is optional.
 `,
+		Attributes: map[string]schema.Attribute{
+			"max_concurrent_per_host": schema.Int64Attribute{
+				Description: "Maximum number of concurrent requests made to a single host. Requests over the limit wait for a free slot. Unlimited when unset.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_headers": schema.MapAttribute{
+				Description: "HTTP headers sent with every request of `utility_file_downloader`, such as the authentication of an API that all downloads use. Headers set in the `headers` of a resource take precedence over default headers of the same name, regardless of case.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"base_url": schema.StringAttribute{
+				Description: "Absolute URL that relative `url`s of `utility_file_downloader` are resolved against, the way a browser resolves links. End it with a slash to resolve below its path: with `https://example.com/api/`, `files/a.zip` becomes `https://example.com/api/files/a.zip`, while `/files/a.zip` becomes `https://example.com/files/a.zip`.",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Default for the `timeout` of `utility_file_downloader`, as a duration such as \"30s\". A `timeout` set on the resource takes precedence; when neither is set, requests never time out.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"retry_max": schema.Int64Attribute{
+				Description: "Default for the `retry_max` of `utility_file_downloader`. A `retry_max` set on the resource takes precedence, even if 0; when neither is set, requests are not retried.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait": schema.StringAttribute{
+				Description: "Default for the `retry_wait` of `utility_file_downloader`, as a duration such as \"2s\". A `retry_wait` set on the resource takes precedence; when neither is set, the first retry waits 1s.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+		},
 	}
 }
 
-func (p *fileDownloaderProvider) Configure(_ context.Context, _ provider.ConfigureRequest, _ *provider.ConfigureResponse) {
+func (p *fileDownloaderProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config fileDownloaderProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := &providerData{
+		hostLimiter:    newHostLimiter(int(config.MaxConcurrentPerHost.ValueInt64())),
+		defaultHeaders: stringMapValue(config.DefaultHeaders),
+		userAgent:      "terraform-provider-utility/" + p.version,
+		retryMax:       int(config.RetryMax.ValueInt64()),
+	}
+	if !config.RequestTimeout.IsNull() {
+		data.requestTimeout, _ = time.ParseDuration(config.RequestTimeout.ValueString())
+	}
+	if !config.RetryWait.IsNull() {
+		data.retryWait, _ = time.ParseDuration(config.RetryWait.ValueString())
+	}
+
+	if !config.BaseURL.IsNull() && !config.BaseURL.IsUnknown() {
+		baseURL, err := url.Parse(config.BaseURL.ValueString())
+		if err != nil || !baseURL.IsAbs() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("base_url"),
+				"Invalid Base URL",
+				fmt.Sprintf("base_url must be an absolute URL such as \"https://example.com/api/\", got: %q", config.BaseURL.ValueString()),
+			)
+			return
+		}
+		data.baseURL = baseURL
+	}
+
+	resp.ResourceData = data
+	resp.DataSourceData = data
 }
 
 func (p *fileDownloaderProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewFileDownloaderResource,
+		NewFileDownloaderBatchResource,
+		NewWaitForHTTPResource,
+		NewWaitForURLResource,
+		NewWaitForContentResource,
+		NewWaitForPortResource,
+		NewHTTPMirrorResource,
+		NewCompressResource,
+		NewArchiveResource,
+		NewUnarchiveResource,
+		NewCopyFileResource,
+		NewFileMoverResource,
+		NewSymlinkResource,
+		NewCommandResource,
+		NewFileTemplateResource,
+		NewLocalFileResource,
+		NewDirectoryResource,
+		NewRandomPasswordResource,
+		NewRandomUUIDResource,
+		NewSleepResource,
 	}
 }
 
 func (p *fileDownloaderProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewDirectoryChecksumDataSource,
+		NewFileHashDataSource,
+		NewFileContentDataSource,
+		NewDNSLookupDataSource,
+		NewPortCheckDataSource,
+		NewHTTPDataSource,
+		NewURLHeadDataSource,
+		NewTemplateFileDataSource,
+	}
+}
+
+func (p *fileDownloaderProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewHTTPTokenEphemeralResource,
+	}
 }
 
-type fileChecksums struct {
-	sha1Hex   string
-	sha256Hex string
+func (p *fileDownloaderProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewChecksumEqualFunction,
+		NewGunzipBase64Function,
+		NewFileSHA256Function,
+		NewFileSHA1Function,
+		NewFileMD5Function,
+		NewJSONMergeFunction,
+		NewJSONPatchFunction,
+		NewJSONPathFunction,
+		NewYAMLEncodeFunction,
+		NewYAMLDecodeFunction,
+	}
 }
 
-func genFileChecksums(data []byte) *fileChecksums {
-	sha1Sum := sha1.Sum(data)
-	sha256Sum := sha256.Sum256(data)
+// applyDefaults resolves a relative opts.url against the base URL, merges
+// the default headers under opts.headers and sets the User-Agent unless
+// opts has one. A nil d leaves opts unchanged.
+func (d *providerData) applyDefaults(opts *downloadOptions) {
+	if d == nil {
+		return
+	}
+
+	if d.baseURL != nil {
+		if ref, err := url.Parse(opts.url); err == nil {
+			opts.url = d.baseURL.ResolveReference(ref).String()
+		}
+	}
+	opts.headers = mergeHeaders(d.defaultHeaders, opts.headers)
+	if opts.userAgent == "" {
+		opts.userAgent = d.userAgent
+	}
+}
+
+// mergeHeaders returns the union of defaults and headers. A header in
+// headers replaces every default of the same name, regardless of case.
+func mergeHeaders(defaults, headers map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(headers))
+	overridden := make(map[string]bool, len(headers))
+	for k, v := range headers {
+		merged[k] = v
+		overridden[http.CanonicalHeaderKey(k)] = true
+	}
+	for k, v := range defaults {
+		if !overridden[http.CanonicalHeaderKey(k)] {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// stringMapValue converts a map of strings to a Go map, skipping null and
+// unknown elements.
+func stringMapValue(m types.Map) map[string]string {
+	out := make(map[string]string, len(m.Elements()))
+	for k, v := range m.Elements() {
+		if strVal, ok := v.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			out[k] = strVal.ValueString()
+		}
+	}
+	return out
+}
+
+// stringListValue converts a list of strings to a Go slice, skipping null
+// and unknown elements.
+func stringListValue(l types.List) []string {
+	out := make([]string, 0, len(l.Elements()))
+	for _, v := range l.Elements() {
+		if strVal, ok := v.(types.String); ok && !strVal.IsNull() && !strVal.IsUnknown() {
+			out = append(out, strVal.ValueString())
+		}
+	}
+	return out
+}
 
-	return &fileChecksums{
-		sha1Hex:   hex.EncodeToString(sha1Sum[:]),
-		sha256Hex: hex.EncodeToString(sha256Sum[:]),
+// stringMapToValue converts a Go map to a map of strings.
+func stringMapToValue(m map[string]string) types.Map {
+	elems := make(map[string]attr.Value, len(m))
+	for k, v := range m {
+		elems[k] = types.StringValue(v)
 	}
+	return types.MapValueMust(types.StringType, elems)
 }
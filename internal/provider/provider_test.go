@@ -4,10 +4,60 @@
 package provider
 
 import (
+	"net/url"
+	"testing"
+
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var protoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"utility": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+func TestMergeHeaders(t *testing.T) {
+	merged := mergeHeaders(
+		map[string]string{"Authorization": "Bearer default", "X-Team": "infra"},
+		map[string]string{"authorization": "Bearer resource", "Accept": "application/json"},
+	)
+	assert.Equal(t, map[string]string{
+		"authorization": "Bearer resource",
+		"Accept":        "application/json",
+		"X-Team":        "infra",
+	}, merged)
+}
+
+func TestProviderData_ApplyDefaults(t *testing.T) {
+	base, err := url.Parse("https://api.example.com/v1/")
+	require.NoError(t, err)
+	data := &providerData{
+		baseURL:        base,
+		defaultHeaders: map[string]string{"X-Api-Key": "secret"},
+	}
+
+	for ref, want := range map[string]string{
+		"files/app.zip":                      "https://api.example.com/v1/files/app.zip",
+		"/files/app.zip":                     "https://api.example.com/files/app.zip",
+		"https://mirror.example.com/app.zip": "https://mirror.example.com/app.zip",
+	} {
+		opts := downloadOptions{url: ref}
+		data.applyDefaults(&opts)
+		assert.Equal(t, want, opts.url)
+		assert.Equal(t, map[string]string{"X-Api-Key": "secret"}, opts.headers)
+	}
+
+	data.userAgent = "terraform-provider-utility/1.2.3"
+	defaulted := downloadOptions{}
+	data.applyDefaults(&defaulted)
+	assert.Equal(t, "terraform-provider-utility/1.2.3", defaulted.userAgent)
+	custom := downloadOptions{userAgent: "custom/1.0"}
+	data.applyDefaults(&custom)
+	assert.Equal(t, "custom/1.0", custom.userAgent)
+
+	var unconfigured *providerData
+	opts := downloadOptions{url: "files/app.zip"}
+	unconfigured.applyDefaults(&opts)
+	assert.Equal(t, "files/app.zip", opts.url)
+}
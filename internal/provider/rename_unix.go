@@ -0,0 +1,12 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+
+package provider
+
+import "syscall"
+
+// errCrossDevice is the error os.Rename fails with when the paths are on
+// different file systems.
+var errCrossDevice error = syscall.EXDEV
@@ -0,0 +1,12 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+
+package provider
+
+import "golang.org/x/sys/windows"
+
+// errCrossDevice is the error os.Rename fails with when the paths are on
+// different volumes.
+var errCrossDevice error = windows.ERROR_NOT_SAME_DEVICE
@@ -0,0 +1,244 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type archiveResource struct{}
+
+func NewArchiveResource() resource.Resource {
+	return &archiveResource{}
+}
+
+func (r *archiveResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_archive"
+}
+
+func (r *archiveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to package the files of a directory into a zip or tar.gz archive. The archive is written atomically and recreated whenever a file in the directory or the archive itself changes on disk. Entries get a fixed modification time and only keep the executable bit of their mode, so the same files always produce the same archive.",
+		Attributes: map[string]schema.Attribute{
+			"source_dir": schema.StringAttribute{
+				Description: "Directory whose files are archived. Paths in the archive are relative to it. Empty directories, symbolic links and other special files are left out.",
+				Required:    true,
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Path where the archive will be saved.",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Archive format (default: zip). Only 'zip' and 'tar.gz' are allowed.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(archiveFormatZip, archiveFormatTarGz),
+				},
+				Default: stringdefault.StaticString(archiveFormatZip),
+			},
+			"exclude": schema.ListAttribute{
+				Description: "Glob patterns, in the syntax of Go's `path.Match`, of files and directories to leave out. Patterns are matched against the path relative to `source_dir` with forward slashes, so `*.tmp` only matches at the top level and `cache/*` matches the entries of `cache`. Excluding a directory excludes everything below it. Changes to excluded files do not recreate the archive.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(globValidator{}),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The hexadecimal encoding of the SHA256 checksum of the archive.",
+				Computed:    true,
+			},
+			"source_sha256": schema.StringAttribute{
+				Description: "Aggregate SHA256 checksum over the paths and contents of the archived files, computed like the `sha256` of `utility_directory_checksum`.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the archive.",
+				Computed:    true,
+			},
+			"size_bytes": schema.Int64Attribute{
+				Description: "Size of the archive in bytes.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *archiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan archiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.archive(ctx); err != nil {
+		resp.Diagnostics.AddError("Archiving Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *archiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state archiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceSha256, err := state.sourceChecksum(ctx)
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	checksums, err := hashFile(state.OutputPath.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	if sourceSha256 != state.SourceSha256.ValueString() || checksums.sha256Hex != state.Sha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *archiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan archiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state archiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.archive(ctx); err != nil {
+		resp.Diagnostics.AddError("Archiving Failed", err.Error())
+		return
+	}
+
+	if state.OutputPath.ValueString() != plan.OutputPath.ValueString() {
+		os.Remove(state.OutputPath.ValueString())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *archiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var outputPath string
+	req.State.GetAttribute(ctx, path.Root("output_path"), &outputPath)
+	os.Remove(outputPath)
+}
+
+type archiveResourceModel struct {
+	SourceDir    types.String `tfsdk:"source_dir"`
+	OutputPath   types.String `tfsdk:"output_path"`
+	Format       types.String `tfsdk:"format"`
+	Exclude      types.List   `tfsdk:"exclude"`
+	ID           types.String `tfsdk:"id"`
+	SourceSha256 types.String `tfsdk:"source_sha256"`
+	Sha256       types.String `tfsdk:"sha256"`
+	SizeBytes    types.Int64  `tfsdk:"size_bytes"`
+}
+
+// sourceFiles returns the files to archive, relative to the source
+// directory. The archive itself is left out in case it is written into the
+// source directory.
+func (m *archiveResourceModel) sourceFiles(ctx context.Context) ([]string, error) {
+	var exclude []string
+	m.Exclude.ElementsAs(ctx, &exclude, false)
+
+	dir := m.SourceDir.ValueString()
+	files, err := archiveSourceFiles(dir, exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	if rel, err := filepath.Rel(dir, m.OutputPath.ValueString()); err == nil {
+		self := filepath.ToSlash(rel)
+		files = slices.DeleteFunc(files, func(name string) bool { return name == self })
+	}
+	return files, nil
+}
+
+// archive writes the archive of the source directory and records the
+// checksums of the archive and of the archived files.
+func (m *archiveResourceModel) archive(ctx context.Context) error {
+	dir := m.SourceDir.ValueString()
+	files, err := m.sourceFiles(ctx)
+	if err != nil {
+		return err
+	}
+
+	var sums map[string]string
+	hasher := newFileHasher()
+	err = writeFileAtomic(m.OutputPath.ValueString(), func(w io.Writer) error {
+		var err error
+		sums, err = writeArchive(io.MultiWriter(w, hasher), dir, m.Format.ValueString(), files)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(m.OutputPath.ValueString())
+	if err != nil {
+		return err
+	}
+
+	checksums := hasher.checksums()
+	m.ID = types.StringValue(checksums.sha256Hex)
+	m.SourceSha256 = types.StringValue(aggregateChecksum(sums))
+	m.Sha256 = types.StringValue(checksums.sha256Hex)
+	m.SizeBytes = types.Int64Value(info.Size())
+
+	return nil
+}
+
+// sourceChecksum returns the aggregate checksum of the files that would be
+// archived now.
+func (m *archiveResourceModel) sourceChecksum(ctx context.Context) (string, error) {
+	dir := m.SourceDir.ValueString()
+	files, err := m.sourceFiles(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	sums := make(map[string]string, len(files))
+	for _, name := range files {
+		checksums, err := hashFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return "", err
+		}
+		sums[name] = checksums.sha256Hex
+	}
+	return aggregateChecksum(sums), nil
+}
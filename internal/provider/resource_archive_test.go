@@ -0,0 +1,93 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveResource(t *testing.T) {
+	src := t.TempDir()
+	writeTestTree(t, src, map[string]string{
+		"config/app.yaml": "replicas: 3",
+		"README.md":       "docs",
+		"scratch.tmp":     "ignored",
+	})
+	out := t.TempDir()
+
+	// checkExtracted extracts the archive and compares it to the files of
+	// src that are not excluded.
+	checkExtracted := func(archivePath string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			dest := t.TempDir()
+			if _, err := extractArchive(archivePath, dest, extractOverwriteAlways); err != nil {
+				return err
+			}
+			want, err := hashTree(src, symlinkPolicyLink)
+			if err != nil {
+				return err
+			}
+			delete(want.files, "scratch.tmp")
+			got, err := hashTree(dest, symlinkPolicyLink)
+			if err != nil {
+				return err
+			}
+			if got.sha256Hex != aggregateChecksum(want.files) {
+				return fmt.Errorf("extracted files %v, want %v", got.files, want.files)
+			}
+			return nil
+		}
+	}
+
+	config := func(format string) string {
+		return fmt.Sprintf(`
+			resource "utility_archive" "bundle" {
+				source_dir = %q
+				output_path = %q
+				format = %q
+				exclude = ["*.tmp"]
+			}`, src, filepath.Join(out, "bundle."+format), format)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(archiveFormatZip),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("utility_archive.bundle", "sha256"),
+					resource.TestCheckResourceAttrSet("utility_archive.bundle", "size_bytes"),
+					checkExtracted(filepath.Join(out, "bundle.zip")),
+				),
+			},
+			{
+				// Changing an archived file recreates the archive.
+				PreConfig: func() {
+					require.NoError(t, os.WriteFile(filepath.Join(src, "config", "app.yaml"), []byte("replicas: 5"), 0o644))
+				},
+				Config: config(archiveFormatZip),
+				Check:  checkExtracted(filepath.Join(out, "bundle.zip")),
+			},
+			{
+				Config: config(archiveFormatTarGz),
+				Check: resource.ComposeTestCheckFunc(
+					checkExtracted(filepath.Join(out, "bundle.tar.gz")),
+					func(*terraform.State) error {
+						if _, err := os.Stat(filepath.Join(out, "bundle.zip")); !os.IsNotExist(err) {
+							return fmt.Errorf("previous archive was not removed: %v", err)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
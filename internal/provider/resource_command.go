@@ -0,0 +1,270 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*commandResource)(nil)
+
+type commandResource struct{}
+
+func NewCommandResource() resource.Resource {
+	return &commandResource{}
+}
+
+func (r *commandResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_command"
+}
+
+func (r *commandResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that runs a local command once, when it is created, and records its output. Unlike a `local-exec` provisioner, the command only runs again when one of its arguments or `triggers` changes or, with `output_file`, when the file it produces is removed or changed on disk. Interrupting Terraform kills the command. Destroying the resource does not run anything.",
+		Attributes: map[string]schema.Attribute{
+			"command": schema.ListAttribute{
+				Description: "The program to run followed by its arguments. The program is looked up in PATH unless it contains a path separator. No shell is involved, so run one explicitly, such as `[\"sh\", \"-c\", \"...\"]`, to use pipes or redirections.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"working_dir": schema.StringAttribute{
+				Description: "Directory to run the command in (default: the working directory of Terraform).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"environment": schema.MapAttribute{
+				Description: "Environment variables to set for the command, on top of the environment of Terraform.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will recreate the resource, running the command again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"ignore_errors": schema.BoolAttribute{
+				Description: "Record a non-zero exit code in `exit_code` instead of failing (default: false). A command that cannot be started always fails.",
+				Optional:    true,
+			},
+			"output_file": schema.StringAttribute{
+				Description: "Path of a file produced by the command, relative to `working_dir`. Its checksum is recorded in `output_sha256` and checked on refresh, and the command runs again if the file was removed or changed. Creating the resource fails if the command does not produce the file.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"stdout": schema.StringAttribute{
+				Description: "Standard output of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stderr": schema.StringAttribute{
+				Description: "Standard error of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"exit_code": schema.Int64Attribute{
+				Description: "Exit code of the command.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"output_sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of `output_file`, if set.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Time the command was run, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type commandResourceModel struct {
+	Command      types.List   `tfsdk:"command"`
+	WorkingDir   types.String `tfsdk:"working_dir"`
+	Environment  types.Map    `tfsdk:"environment"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	IgnoreErrors types.Bool   `tfsdk:"ignore_errors"`
+	OutputFile   types.String `tfsdk:"output_file"`
+	Stdout       types.String `tfsdk:"stdout"`
+	Stderr       types.String `tfsdk:"stderr"`
+	ExitCode     types.Int64  `tfsdk:"exit_code"`
+	OutputSha256 types.String `tfsdk:"output_sha256"`
+	ID           types.String `tfsdk:"id"`
+}
+
+func (r *commandResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan commandResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var args []string
+	resp.Diagnostics.Append(plan.Command.ElementsAs(ctx, &args, false)...)
+	env := map[string]string{}
+	if !plan.Environment.IsNull() {
+		resp.Diagnostics.Append(plan.Environment.ElementsAs(ctx, &env, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := runCommand(ctx, args, plan.WorkingDir.ValueString(), env)
+	if err != nil {
+		resp.Diagnostics.AddError("Command Failed", fmt.Sprintf("Running %s: %s", args[0], err))
+		return
+	}
+	if result.exitCode != 0 && !plan.IgnoreErrors.ValueBool() {
+		resp.Diagnostics.AddError("Command Failed", fmt.Sprintf("%s exited with code %d: %s", args[0], result.exitCode, strings.TrimSpace(result.stderr)))
+		return
+	}
+
+	plan.Stdout = types.StringValue(result.stdout)
+	plan.Stderr = types.StringValue(result.stderr)
+	plan.ExitCode = types.Int64Value(int64(result.exitCode))
+	plan.OutputSha256 = types.StringNull()
+	if !plan.OutputFile.IsNull() {
+		checksums, err := hashFile(commandOutputPath(plan))
+		if err != nil {
+			resp.Diagnostics.AddError("Command Failed", fmt.Sprintf("Reading the output file of %s: %s", args[0], err))
+			return
+		}
+		plan.OutputSha256 = types.StringValue(checksums.sha256Hex)
+	}
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *commandResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state commandResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OutputFile.IsNull() {
+		return
+	}
+	checksums, err := hashFile(commandOutputPath(state))
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	if checksums.sha256Hex != state.OutputSha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+	}
+}
+
+func (r *commandResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only ignore_errors can change without running the command again.
+	var plan commandResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *commandResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// commandOutputPath returns the path of the output file of m, resolved from
+// its working directory.
+func commandOutputPath(m commandResourceModel) string {
+	path := m.OutputFile.ValueString()
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(m.WorkingDir.ValueString(), path)
+}
+
+type commandResult struct {
+	stdout   string
+	stderr   string
+	exitCode int
+}
+
+// runCommand runs args in dir, with env added to the environment of the
+// provider, and waits for it to exit. A non-zero exit code is returned in the
+// result rather than as an error; the error is reserved for commands that
+// could not be run or were killed because ctx was done.
+func runCommand(ctx context.Context, args []string, dir string, env map[string]string) (*commandResult, error) {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for name, value := range env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	result := &commandResult{stdout: stdout.String(), stderr: stderr.String()}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.exitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandResource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands need a POSIX shell")
+	}
+	dir := t.TempDir()
+	output := filepath.Join(dir, "version.txt")
+	sum := sha256.Sum256([]byte("1.2.3\n"))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "utility_command" "echo" {
+						command = ["echo", "hello", "world"]
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_command.echo", "stdout", "hello world\n"),
+					resource.TestCheckResourceAttr("utility_command.echo", "stderr", ""),
+					resource.TestCheckResourceAttr("utility_command.echo", "exit_code", "0"),
+					resource.TestCheckNoResourceAttr("utility_command.echo", "output_sha256"),
+					resource.TestCheckResourceAttrSet("utility_command.echo", "id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_command" "version" {
+						command = ["sh", "-c", "echo $VERSION > version.txt"]
+						working_dir = %q
+						environment = {
+							VERSION = "1.2.3"
+						}
+						output_file = "version.txt"
+					}`, dir),
+				Check: resource.TestCheckResourceAttr("utility_command.version", "output_sha256", hex.EncodeToString(sum[:])),
+			},
+			{
+				// A removed output file runs the command again.
+				PreConfig:          func() { require.NoError(t, os.Remove(output)) },
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: `
+					resource "utility_command" "failing" {
+						command = ["sh", "-c", "echo broken >&2; exit 3"]
+					}`,
+				ExpectError: regexp.MustCompile(`exited with code 3: broken`),
+			},
+			{
+				Config: `
+					resource "utility_command" "ignored" {
+						command = ["sh", "-c", "echo broken >&2; exit 3"]
+						ignore_errors = true
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_command.ignored", "exit_code", "3"),
+					resource.TestCheckResourceAttr("utility_command.ignored", "stderr", "broken\n"),
+				),
+			},
+		},
+	})
+}
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test commands need a POSIX shell")
+	}
+	ctx := context.Background()
+
+	result, err := runCommand(ctx, []string{"sh", "-c", "echo $GREETING; pwd"}, "/", map[string]string{"GREETING": "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, &commandResult{stdout: "hi\n/\n"}, result)
+
+	result, err = runCommand(ctx, []string{"sh", "-c", "exit 7"}, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 7, result.exitCode)
+
+	_, err = runCommand(ctx, []string{"utility-command-that-does-not-exist"}, "", nil)
+	assert.Error(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = runCommand(canceled, []string{"sleep", "10"}, "", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	compressFormatGzip = "gzip"
+	compressFormatZstd = "zstd"
+	compressFormatXz   = "xz"
+)
+
+var _ resource.ResourceWithValidateConfig = (*compressResource)(nil)
+
+type compressResource struct{}
+
+func NewCompressResource() resource.Resource {
+	return &compressResource{}
+}
+
+func (r *compressResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_compress"
+}
+
+func (r *compressResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to compress a local file with gzip, zstd or xz. The output is written atomically and recreated whenever the input or output changes on disk.",
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				Description: "Path of the file to compress.",
+				Required:    true,
+			},
+			"destination": schema.StringAttribute{
+				Description: "Path where the compressed file will be saved.",
+				Required:    true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Compression format (default: gzip). Only 'gzip', 'zstd' and 'xz' are allowed.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(compressFormatGzip, compressFormatZstd, compressFormatXz),
+				},
+				Default: stringdefault.StaticString(compressFormatGzip),
+			},
+			"level": schema.Int64Attribute{
+				Description: "Compression level. gzip accepts 1 (fastest) to 9 (best), zstd accepts 1 to 22 and xz accepts 0 to 9, mirroring the presets of the respective command line tools. Defaults to the format's default level.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The hexadecimal encoding of the SHA1 checksum of the compressed file content.",
+				Computed:    true,
+			},
+			"source_sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the source file content.",
+				Computed:    true,
+			},
+			"sha1": schema.StringAttribute{
+				Description: "SHA1 checksum of the compressed file content.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the compressed file content.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *compressResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config compressResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Level.IsNull() || config.Level.IsUnknown() || config.Format.IsUnknown() {
+		return
+	}
+
+	format := compressFormatGzip
+	if !config.Format.IsNull() {
+		format = config.Format.ValueString()
+	}
+	levels, ok := compressLevels[format]
+	if !ok {
+		return
+	}
+
+	if level := config.Level.ValueInt64(); level < int64(levels[0]) || level > int64(levels[1]) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("level"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("level must be between %d and %d for format %s, got: %d.", levels[0], levels[1], format, level),
+		)
+	}
+}
+
+func (r *compressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan compressResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.compress(); err != nil {
+		resp.Diagnostics.AddError("Compression Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *compressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state compressResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceChecksums, err := hashFile(state.Source.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	checksums, err := hashFile(state.Destination.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	if sourceChecksums.sha256Hex != state.SourceSha256.ValueString() || checksums.sha256Hex != state.Sha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *compressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan compressResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state compressResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.compress(); err != nil {
+		resp.Diagnostics.AddError("Compression Failed", err.Error())
+		return
+	}
+
+	if state.Destination.ValueString() != plan.Destination.ValueString() {
+		os.Remove(state.Destination.ValueString())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *compressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var destination string
+	req.State.GetAttribute(ctx, path.Root("destination"), &destination)
+	os.Remove(destination)
+}
+
+type compressResourceModel struct {
+	Source       types.String `tfsdk:"source"`
+	Destination  types.String `tfsdk:"destination"`
+	Format       types.String `tfsdk:"format"`
+	Level        types.Int64  `tfsdk:"level"`
+	ID           types.String `tfsdk:"id"`
+	SourceSha256 types.String `tfsdk:"source_sha256"`
+	Sha1         types.String `tfsdk:"sha1"`
+	Sha256       types.String `tfsdk:"sha256"`
+}
+
+// compress streams the source through the configured compressor into the
+// destination and records the checksums of both files.
+func (m *compressResourceModel) compress() error {
+	var level *int
+	if !m.Level.IsNull() {
+		l := int(m.Level.ValueInt64())
+		level = &l
+	}
+
+	source, checksums, err := compressFile(m.Source.ValueString(), m.Destination.ValueString(), m.Format.ValueString(), level)
+	if err != nil {
+		return err
+	}
+
+	m.ID = types.StringValue(checksums.sha1Hex)
+	m.SourceSha256 = types.StringValue(source.sha256Hex)
+	m.Sha1 = types.StringValue(checksums.sha1Hex)
+	m.Sha256 = types.StringValue(checksums.sha256Hex)
+
+	return nil
+}
+
+// compressFile writes src compressed in format to dest atomically and
+// returns the checksums of both files. A nil level selects the format's
+// default.
+func compressFile(src, dest, format string, level *int) (source, compressed *fileChecksums, err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer in.Close()
+
+	sourceHasher := newFileHasher()
+	err = writeFileAtomic(dest, func(w io.Writer) error {
+		outHasher := newFileHasher()
+		cw, err := newCompressWriter(io.MultiWriter(w, outHasher), format, level)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(cw, io.TeeReader(in, sourceHasher)); err != nil {
+			return err
+		}
+		if err := cw.Close(); err != nil {
+			return err
+		}
+		compressed = outHasher.checksums()
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sourceHasher.checksums(), compressed, nil
+}
+
+// xzDictCaps maps the xz command line presets 0-9 to their dictionary sizes.
+var xzDictCaps = []int{
+	256 << 10, 1 << 20, 2 << 20, 4 << 20, 4 << 20,
+	8 << 20, 8 << 20, 16 << 20, 32 << 20, 64 << 20,
+}
+
+// compressLevels holds the lowest and highest compression level of each
+// format.
+var compressLevels = map[string][2]int{
+	compressFormatGzip: {gzip.BestSpeed, gzip.BestCompression},
+	compressFormatZstd: {1, 22},
+	compressFormatXz:   {0, len(xzDictCaps) - 1},
+}
+
+// newCompressWriter returns a writer compressing into w. A nil level selects
+// the format's default.
+func newCompressWriter(w io.Writer, format string, level *int) (io.WriteCloser, error) {
+	if levels, ok := compressLevels[format]; ok && level != nil && (*level < levels[0] || *level > levels[1]) {
+		return nil, fmt.Errorf("%s: invalid compression level: %d", format, *level)
+	}
+
+	switch format {
+	case compressFormatGzip:
+		if level == nil {
+			return gzip.NewWriter(w), nil
+		}
+		return gzip.NewWriterLevel(w, *level)
+	case compressFormatZstd:
+		var opts []zstd.EOption
+		if level != nil {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(*level)))
+		}
+		return zstd.NewWriter(w, opts...)
+	case compressFormatXz:
+		cfg := xz.WriterConfig{}
+		if level != nil {
+			cfg.DictCap = xzDictCaps[*level]
+		}
+		return cfg.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unsupported compression format %q", format)
+	}
+}
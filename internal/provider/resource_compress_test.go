@@ -0,0 +1,115 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ulikunitz/xz"
+)
+
+func TestCompressResource(t *testing.T) {
+	want := []byte(testRandString(1024))
+	dir := t.TempDir()
+	source := filepath.Join(dir, "input.txt")
+	require.NoError(t, os.WriteFile(source, want, 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// gzip has no level 0, unlike xz.
+				Config: fmt.Sprintf(`
+					resource "utility_compress" "gz" {
+						source = %q
+						destination = %q
+						level = 0
+					}`, source, filepath.Join(dir, "input.txt.gz")),
+				ExpectError: regexp.MustCompile(`level must be between 1 and 9 for format gzip`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_compress" "gz" {
+						source = %q
+						destination = %q
+						level = 9
+					}`, source, filepath.Join(dir, "input.txt.gz")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("utility_compress.gz", "sha256"),
+					resource.TestCheckResourceAttrWith("utility_compress.gz", "destination", func(value string) error {
+						f, err := os.Open(value)
+						if err != nil {
+							return err
+						}
+						defer f.Close()
+						zr, err := gzip.NewReader(f)
+						if err != nil {
+							return err
+						}
+						got, err := io.ReadAll(zr)
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, want, got)
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestNewCompressWriter(t *testing.T) {
+	want := []byte(testRandString(4096))
+	level := 3
+
+	for _, format := range []string{compressFormatGzip, compressFormatZstd, compressFormatXz} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newCompressWriter(&buf, format, &level)
+			require.NoError(t, err)
+			_, err = w.Write(want)
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			var r io.Reader
+			switch format {
+			case compressFormatGzip:
+				r, err = gzip.NewReader(&buf)
+			case compressFormatZstd:
+				r, err = zstd.NewReader(&buf)
+			case compressFormatXz:
+				r, err = xz.NewReader(&buf)
+			}
+			require.NoError(t, err)
+
+			got, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	_, err := newCompressWriter(io.Discard, compressFormatZstd, new(int))
+	assert.ErrorContains(t, err, "invalid compression level")
+
+	// gzip would otherwise accept its special levels 0, -1 and -2.
+	for _, level := range []int{0, gzip.DefaultCompression, gzip.HuffmanOnly, 10} {
+		_, err = newCompressWriter(io.Discard, compressFormatGzip, &level)
+		assert.ErrorContains(t, err, "invalid compression level", level)
+	}
+
+	_, err = newCompressWriter(io.Discard, compressFormatXz, new(int))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,170 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type copyFileResource struct{}
+
+func NewCopyFileResource() resource.Resource {
+	return &copyFileResource{}
+}
+
+func (r *copyFileResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_copy_file"
+}
+
+func (r *copyFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to copy a local file to another path. The copy is written atomically and its checksum is verified against the source. The copy is recreated whenever the source or the destination changes on disk.",
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				Description: "Path of the file to copy.",
+				Required:    true,
+			},
+			"destination": schema.StringAttribute{
+				Description: "Path where the copy will be saved.",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The hexadecimal encoding of the SHA1 checksum of the file content.",
+				Computed:    true,
+			},
+			"sha1": schema.StringAttribute{
+				Description: "SHA1 checksum of file content.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of file content.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *copyFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan copyFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.copy(); err != nil {
+		resp.Diagnostics.AddError("Copy Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *copyFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state copyFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, p := range []string{state.Source.ValueString(), state.Destination.ValueString()} {
+		checksums, err := hashFile(p)
+		if os.IsNotExist(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Read Failed", err.Error())
+			return
+		}
+
+		if checksums.sha256Hex != state.Sha256.ValueString() {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *copyFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan copyFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state copyFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.copy(); err != nil {
+		resp.Diagnostics.AddError("Copy Failed", err.Error())
+		return
+	}
+
+	if state.Destination.ValueString() != plan.Destination.ValueString() {
+		os.Remove(state.Destination.ValueString())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *copyFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var destination string
+	req.State.GetAttribute(ctx, path.Root("destination"), &destination)
+	os.Remove(destination)
+}
+
+type copyFileResourceModel struct {
+	Source      types.String `tfsdk:"source"`
+	Destination types.String `tfsdk:"destination"`
+	ID          types.String `tfsdk:"id"`
+	Sha1        types.String `tfsdk:"sha1"`
+	Sha256      types.String `tfsdk:"sha256"`
+}
+
+// copy streams the source into the destination, verifies that the written
+// file has the checksum of the source and records the checksums.
+func (m *copyFileResourceModel) copy() error {
+	in, err := os.Open(m.Source.ValueString())
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	destination := m.Destination.ValueString()
+
+	var checksums *fileChecksums
+	err = writeFileAtomic(destination, func(w io.Writer) error {
+		var err error
+		_, checksums, err = copyAndHash(w, in, -1, 0)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	written, err := hashFile(destination)
+	if err != nil {
+		return err
+	}
+	if written.sha256Hex != checksums.sha256Hex {
+		os.Remove(destination)
+		return fmt.Errorf("checksum of %s (%s) does not match the source (%s)", destination, written.sha256Hex, checksums.sha256Hex)
+	}
+
+	m.ID = types.StringValue(checksums.sha1Hex)
+	m.Sha1 = types.StringValue(checksums.sha1Hex)
+	m.Sha256 = types.StringValue(checksums.sha256Hex)
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFileResource(t *testing.T) {
+	want := []byte(testRandString(32))
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.txt")
+	require.NoError(t, os.WriteFile(source, want, 0o644))
+
+	sha256Sum := sha256.Sum256(want)
+	sha256Hex := hex.EncodeToString(sha256Sum[:])
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_copy_file" "copy" {
+						source = %q
+						destination = %q
+					}`, source, filepath.Join(dir, "nested", "copy.txt")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_copy_file.copy", "sha256", sha256Hex),
+					resource.TestCheckResourceAttrWith("utility_copy_file.copy", "destination", func(value string) error {
+						got, err := os.ReadFile(value)
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, want, got)
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,204 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*directoryResource)(nil)
+
+type directoryResource struct{}
+
+func NewDirectoryResource() resource.Resource {
+	return &directoryResource{}
+}
+
+func (r *directoryResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_directory"
+}
+
+func (r *directoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that ensures a directory exists with the given permissions. An existing directory is adopted as is, apart from its mode. The directory is created again if it is removed, and its mode is set back if it is changed outside of Terraform. Destroying the resource leaves the directory alone unless `delete_on_destroy` is set.",
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Path of the directory. Missing parent directories are created with the mode \"0755\", subject to the umask.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "Permissions of the directory as an octal string, such as \"0700\". The mode is set exactly, regardless of the umask, and checked on refresh. On Windows, where directories have no such permissions, it is not checked. Defaults to \"0755\".",
+				Optional:    true,
+				Validators: []validator.String{
+					fileModeValidator{},
+				},
+			},
+			"delete_on_destroy": schema.BoolAttribute{
+				Description: "Remove the directory when the resource is destroyed (default: false). Removing a directory that is not empty fails unless `force` is set.",
+				Optional:    true,
+			},
+			"force": schema.BoolAttribute{
+				Description: "With `delete_on_destroy`, remove the directory together with everything in it (default: false).",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The path of the directory.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type directoryResourceModel struct {
+	Path            types.String `tfsdk:"path"`
+	Mode            types.String `tfsdk:"mode"`
+	DeleteOnDestroy types.Bool   `tfsdk:"delete_on_destroy"`
+	Force           types.Bool   `tfsdk:"force"`
+	ID              types.String `tfsdk:"id"`
+}
+
+func (r *directoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan directoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.ensure(); err != nil {
+		resp.Diagnostics.AddError("Directory Failed", err.Error())
+		return
+	}
+	plan.ID = plan.Path
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *directoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state directoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := state.refresh()
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *directoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan directoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.ensure(); err != nil {
+		resp.Diagnostics.AddError("Directory Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *directoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state directoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeleteOnDestroy.ValueBool() {
+		return
+	}
+	if err := removeDirectory(state.Path.ValueString(), state.Force.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Delete Failed", err.Error())
+	}
+}
+
+// mode returns the configured mode of the directory.
+func (m *directoryResourceModel) mode() os.FileMode {
+	if m.Mode.IsNull() {
+		return 0o755
+	}
+	mode, _ := parseFileMode(m.Mode.ValueString())
+	return mode
+}
+
+// ensure creates the directory of m if it does not exist and sets its mode.
+func (m *directoryResourceModel) ensure() error {
+	path := m.Path.ValueString()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path, m.mode()); err != nil {
+		return err
+	}
+	// MkdirAll applies the umask and leaves existing directories alone.
+	return os.Chmod(path, m.mode())
+}
+
+// refresh reports whether the directory of m still exists. If its mode was
+// changed, the actual mode is recorded in m so that an update sets it back.
+func (m *directoryResourceModel) refresh() (bool, error) {
+	info, err := os.Stat(m.Path.ValueString())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return false, nil
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm() != m.mode() {
+		m.Mode = types.StringValue(fmt.Sprintf("%04o", info.Mode().Perm()))
+	}
+	return true, nil
+}
+
+// removeDirectory removes the directory at path, which must be empty unless
+// force is set. A directory that no longer exists is not an error.
+func removeDirectory(path string, force bool) error {
+	if force {
+		return os.RemoveAll(path)
+	}
+	err := os.Remove(path)
+	if err == nil || os.IsNotExist(err) {
+		return nil
+	}
+	if entries, _ := os.ReadDir(path); len(entries) > 0 {
+		return fmt.Errorf("%s is not empty; set force to remove it with its contents", path)
+	}
+	return err
+}
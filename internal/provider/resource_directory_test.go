@@ -0,0 +1,187 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryResource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("directory modes are not supported on Windows")
+	}
+	dir := filepath.Join(t.TempDir(), "data", "cache")
+
+	checkMode := func(want os.FileMode) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			info, err := os.Stat(dir)
+			if err != nil {
+				return err
+			}
+			if info.Mode().Perm() != want {
+				return fmt.Errorf("mode of %s is %04o, want %04o", dir, info.Mode().Perm(), want)
+			}
+			return nil
+		}
+	}
+
+	config := fmt.Sprintf(`
+		resource "utility_directory" "cache" {
+			path              = %q
+			mode              = "0700"
+			delete_on_destroy = true
+		}`, dir)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_directory.cache", "id", dir),
+					checkMode(0o700),
+				),
+			},
+			{
+				// A changed mode is set back.
+				PreConfig: func() {
+					require.NoError(t, os.Chmod(dir, 0o755))
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_directory.cache", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkMode(0o700),
+			},
+			{
+				// A removed directory is created again.
+				PreConfig: func() {
+					require.NoError(t, os.Remove(dir))
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_directory.cache", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkMode(0o700),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(dir); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", dir, err)
+			}
+			return nil
+		},
+	})
+}
+
+func TestDirectoryResource_DeleteNotEmpty(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+
+	config := func(force bool) string {
+		return fmt.Sprintf(`
+			resource "utility_directory" "logs" {
+				path              = %q
+				delete_on_destroy = true
+				force             = %t
+			}`, dir, force)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+			},
+			{
+				PreConfig: func() {
+					require.NoError(t, os.WriteFile(filepath.Join(dir, "app.log"), []byte("started\n"), 0o644))
+				},
+				Config:      config(false),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`is not empty; set force to remove it with its contents`),
+			},
+			{
+				// With force, the directory is removed with its contents.
+				Config: config(true),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(dir); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", dir, err)
+			}
+			return nil
+		},
+	})
+}
+
+func TestDirectoryResourceModel_Ensure(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	m := directoryResourceModel{Path: types.StringValue(dir), Mode: types.StringValue("0750")}
+	require.NoError(t, m.ensure())
+
+	exists, err := m.refresh()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, "0750", m.Mode.ValueString())
+
+	// An existing directory is adopted and its mode set.
+	m.Mode = types.StringNull()
+	require.NoError(t, m.ensure())
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(dir)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+		require.NoError(t, os.Chmod(dir, 0o700))
+		_, err = m.refresh()
+		require.NoError(t, err)
+		assert.Equal(t, "0700", m.Mode.ValueString())
+	}
+
+	require.NoError(t, os.Remove(dir))
+	exists, err = m.refresh()
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// A file in place of the directory fails the creation.
+	require.NoError(t, os.WriteFile(dir, nil, 0o644))
+	require.Error(t, m.ensure())
+}
+
+func TestRemoveDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dir")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "file.txt"), []byte("content"), 0o644))
+
+	err := removeDirectory(dir, false)
+	require.EqualError(t, err, dir+" is not empty; set force to remove it with its contents")
+	assert.DirExists(t, dir)
+
+	require.NoError(t, removeDirectory(dir, true))
+	assert.NoDirExists(t, dir)
+
+	// Removing a directory that is already gone succeeds either way.
+	require.NoError(t, removeDirectory(dir, false))
+	require.NoError(t, removeDirectory(dir, true))
+
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	require.NoError(t, removeDirectory(dir, false))
+	assert.NoDirExists(t, dir)
+}
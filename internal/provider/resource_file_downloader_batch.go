@@ -0,0 +1,343 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultBatchConcurrency is the number of files downloaded at the same time
+// when max_concurrency is not set.
+const defaultBatchConcurrency = 4
+
+var (
+	_ resource.ResourceWithConfigure      = (*fileDownloaderBatchResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*fileDownloaderBatchResource)(nil)
+)
+
+type fileDownloaderBatchResource struct {
+	providerData *providerData
+}
+
+func NewFileDownloaderBatchResource() resource.Resource {
+	return &fileDownloaderBatchResource{}
+}
+
+func (r *fileDownloaderBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *fileDownloaderBatchResource) hostLimiter() *hostLimiter {
+	if r.providerData == nil {
+		return nil
+	}
+	return r.providerData.hostLimiter
+}
+
+func (r *fileDownloaderBatchResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_file_downloader_batch"
+}
+
+func (r *fileDownloaderBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to download a list of files via HTTP(S) GET concurrently, keeping many small downloads in a single resource. Every file is written atomically like with `utility_file_downloader`. If any download fails, the errors of all failed downloads are reported together and the files that were downloaded are removed. The files are downloaded again if any of them is removed or changed on disk, and removed when the resource is destroyed. The provider's `base_url`, `default_headers`, `request_timeout`, `retry_max` and `retry_wait` apply to every download.",
+		Attributes: map[string]schema.Attribute{
+			"files": schema.ListNestedAttribute{
+				Description: "Files to download. Changing the list downloads all files again.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							Description: "The full HTTP or HTTPS URL of the file, or a URL relative to the `base_url` of the provider.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"filename": schema.StringAttribute{
+							Description: "Local filename where the file will be saved. Missing directories are created. Every file must have a different filename.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in every request. The values are redacted in the plan, and can reference environment variables as `$${env:NAME}` or `$${env:NAME:-default}`. Changing the headers does not download the files again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Description: "Maximum number of files downloaded at the same time (default: 4). Requests to a host are further limited by the `max_concurrent_per_host` of the provider.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"checksums": schema.MapAttribute{
+				Description: "Map of the filenames to the SHA256 checksums of the downloaded files.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "SHA256 checksum of the list of checksums and filenames of the downloaded files, in the format of `sha256sum`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type fileDownloaderBatchResourceModel struct {
+	Files          types.List   `tfsdk:"files"`
+	Headers        types.Map    `tfsdk:"headers"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	Checksums      types.Map    `tfsdk:"checksums"`
+	ID             types.String `tfsdk:"id"`
+}
+
+type batchFileModel struct {
+	URL      types.String `tfsdk:"url"`
+	Filename types.String `tfsdk:"filename"`
+}
+
+func (r *fileDownloaderBatchResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config fileDownloaderBatchResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Files.IsUnknown() {
+		return
+	}
+
+	var files []batchFileModel
+	resp.Diagnostics.Append(config.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool, len(files))
+	for i, file := range files {
+		if file.Filename.IsUnknown() || file.Filename.IsNull() {
+			continue
+		}
+		filename := file.Filename.ValueString()
+		if seen[filename] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files").AtListIndex(i).AtName("filename"),
+				"Duplicate Filename",
+				fmt.Sprintf("%s is the filename of more than one file.", filename),
+			)
+		}
+		seen[filename] = true
+	}
+}
+
+func (r *fileDownloaderBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileDownloaderBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var files []batchFileModel
+	resp.Diagnostics.Append(plan.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	concurrency := defaultBatchConcurrency
+	if !plan.MaxConcurrency.IsNull() {
+		concurrency = int(plan.MaxConcurrency.ValueInt64())
+	}
+	checksums, err := r.downloadAll(ctx, files, stringMapValue(plan.Headers), concurrency)
+	if err != nil {
+		resp.Diagnostics.AddError("Download Failed", err.Error())
+		return
+	}
+
+	plan.Checksums = stringMapToValue(checksums)
+	plan.ID = types.StringValue(batchID(files, checksums))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileDownloaderBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileDownloaderBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Any file that is missing or changed means the batch has to be
+	// downloaded again, so let Terraform recreate it.
+	for filename, sha256Hex := range stringMapValue(state.Checksums) {
+		checksums, err := hashFile(filename)
+		if os.IsNotExist(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Read Failed", err.Error())
+			return
+		}
+		if checksums.sha256Hex != sha256Hex {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *fileDownloaderBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only headers and max_concurrency can change without replacing the
+	// resource, and neither affects the files already downloaded.
+	var plan fileDownloaderBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileDownloaderBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileDownloaderBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var errs []error
+	for filename := range stringMapValue(state.Checksums) {
+		if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		resp.Diagnostics.AddError("Delete Failed", err.Error())
+	}
+}
+
+// downloadAll downloads files with at most concurrency downloads at a time
+// and returns their SHA256 checksums by filename. All files are attempted
+// even if some fail, and the errors of all failed downloads are returned
+// together after the files that were downloaded are removed.
+func (r *fileDownloaderBatchResource) downloadAll(ctx context.Context, files []batchFileModel, headers map[string]string, concurrency int) (map[string]string, error) {
+	checksums := make([]string, len(files))
+	errs := make([]error, len(files))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			opts := r.downloadOptions(file, headers)
+			result, err := downloadFile(ctx, r.hostLimiter(), opts)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", file.URL.ValueString(), err)
+				return
+			}
+			checksums[i] = result.sha256Hex
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		// The resource is not created, so remove the files that were
+		// downloaded rather than leave them behind untracked.
+		for i, file := range files {
+			if errs[i] != nil {
+				continue
+			}
+			if rmErr := os.Remove(file.Filename.ValueString()); rmErr != nil && !os.IsNotExist(rmErr) {
+				err = errors.Join(err, rmErr)
+			}
+		}
+		return nil, err
+	}
+
+	byFilename := make(map[string]string, len(files))
+	for i, file := range files {
+		byFilename[file.Filename.ValueString()] = checksums[i]
+	}
+	return byFilename, nil
+}
+
+// downloadOptions returns the options for downloading file, with the
+// defaults of the provider configuration applied.
+func (r *fileDownloaderBatchResource) downloadOptions(file batchFileModel, headers map[string]string) downloadOptions {
+	opts := downloadOptions{
+		method:  http.MethodGet,
+		url:     file.URL.ValueString(),
+		path:    file.Filename.ValueString(),
+		headers: headers,
+	}
+	r.providerData.applyDefaults(&opts)
+	if d := r.providerData; d != nil {
+		opts.timeout = d.requestTimeout
+		opts.retryMax = d.retryMax
+		opts.retryWait = d.retryWait
+	}
+	return opts
+}
+
+// batchID returns the SHA256 checksum of a listing of files and checksums in
+// the format of sha256sum.
+func batchID(files []batchFileModel, checksums map[string]string) string {
+	h := sha256.New()
+	for _, file := range files {
+		fmt.Fprintf(h, "%s  %s\n", checksums[file.Filename.ValueString()], file.Filename.ValueString())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
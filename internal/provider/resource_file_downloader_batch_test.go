@@ -0,0 +1,169 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDownloaderBatchResource(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	names := []string{"a.txt", "b.txt", "sub/c.txt", "d.txt", "e.txt"}
+	var files []string
+	var checks []resource.TestCheckFunc
+	for _, name := range names {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		files = append(files, fmt.Sprintf("{ url = %q, filename = %q }", ts.URL+"/"+name, filename))
+		sum := sha256.Sum256([]byte("content of /" + name))
+		checks = append(checks,
+			resource.TestCheckResourceAttr("utility_file_downloader_batch.files", "checksums."+filename, hex.EncodeToString(sum[:])),
+			testCheckFileContent(t, filename, "content of /"+name),
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_file_downloader_batch" "files" {
+						files           = [%s]
+						max_concurrency = 2
+					}`, strings.Join(files, ", ")),
+				Check: resource.ComposeTestCheckFunc(append(checks,
+					resource.TestCheckResourceAttr("utility_file_downloader_batch.files", "checksums.%", "5"),
+				)...),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			for _, name := range names {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+					return fmt.Errorf("%s was not removed: %v", name, err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestFileDownloaderBatchResource_DuplicateFilename(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "file.txt")
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_file_downloader_batch" "duplicate" {
+						files = [
+							{ url = "https://example.com/a", filename = %q },
+							{ url = "https://example.com/b", filename = %q },
+						]
+					}`, filename, filename),
+				ExpectError: regexp.MustCompile(`is the filename of more than one file`),
+			},
+		},
+	})
+}
+
+func testCheckFileContent(t *testing.T, filename, want string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		got, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, want, string(got), filename)
+		return nil
+	}
+}
+
+func testBatchFiles(baseURL, dir string, names ...string) []batchFileModel {
+	files := make([]batchFileModel, len(names))
+	for i, name := range names {
+		files[i] = batchFileModel{
+			URL:      types.StringValue(baseURL + "/" + name),
+			Filename: types.StringValue(filepath.Join(dir, name)),
+		}
+	}
+	return files
+}
+
+func TestFileDownloaderBatchResource_DownloadAll(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(r.URL.Path))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	files := testBatchFiles(ts.URL, dir, "1", "2", "3", "4", "5", "6", "7", "8")
+	r := &fileDownloaderBatchResource{}
+	checksums, err := r.downloadAll(context.Background(), files, nil, 3)
+	require.NoError(t, err)
+
+	assert.Len(t, checksums, len(files))
+	for _, file := range files {
+		name := filepath.Base(file.Filename.ValueString())
+		sum := sha256.Sum256([]byte("/" + name))
+		assert.Equal(t, hex.EncodeToString(sum[:]), checksums[file.Filename.ValueString()], name)
+	}
+	assert.Greater(t, maxInFlight, 1, "downloads run concurrently")
+	assert.LessOrEqual(t, maxInFlight, 3, "at most max_concurrency downloads run at a time")
+}
+
+func TestFileDownloaderBatchResource_DownloadAllErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	r := &fileDownloaderBatchResource{}
+	_, err := r.downloadAll(context.Background(), testBatchFiles(ts.URL, dir, "missing-1", "ok", "missing-2"), nil, 2)
+	require.Error(t, err)
+
+	// Every failure is reported, and the file that was downloaded is
+	// removed again.
+	assert.Contains(t, err.Error(), ts.URL+"/missing-1: ")
+	assert.Contains(t, err.Error(), ts.URL+"/missing-2: ")
+	assert.NotContains(t, err.Error(), ts.URL+"/ok")
+	assert.NoFileExists(t, filepath.Join(dir, "ok"))
+}
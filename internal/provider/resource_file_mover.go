@@ -0,0 +1,198 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*fileMoverResource)(nil)
+
+type fileMoverResource struct{}
+
+func NewFileMoverResource() resource.Resource {
+	return &fileMoverResource{}
+}
+
+func (r *fileMoverResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_file_mover"
+}
+
+func (r *fileMoverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to move a local file to another path, such as an artifact downloaded to a staging path into its final place. On the same file system the file is renamed, which replaces the destination atomically; across file systems it is copied atomically and the source is removed. The move is done again if the destination is removed or changed on disk, which fails unless the source exists again.",
+		Attributes: map[string]schema.Attribute{
+			"source": schema.StringAttribute{
+				Description: "Path of the file to move.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description: "Path the file is moved to. Missing directories are created, and an existing file is replaced.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delete_destination": schema.BoolAttribute{
+				Description: "Remove the destination file when the resource is destroyed (default: false).",
+				Optional:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the moved file, checked on refresh to detect changes to the destination.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The SHA256 checksum of the moved file.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type fileMoverResourceModel struct {
+	Source            types.String `tfsdk:"source"`
+	Destination       types.String `tfsdk:"destination"`
+	DeleteDestination types.Bool   `tfsdk:"delete_destination"`
+	Sha256            types.String `tfsdk:"sha256"`
+	ID                types.String `tfsdk:"id"`
+}
+
+func (r *fileMoverResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileMoverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destination := plan.Destination.ValueString()
+	if err := moveFile(plan.Source.ValueString(), destination, os.Rename); err != nil {
+		resp.Diagnostics.AddError("Move Failed", err.Error())
+		return
+	}
+
+	checksums, err := hashFile(destination)
+	if err != nil {
+		resp.Diagnostics.AddError("Move Failed", err.Error())
+		return
+	}
+	plan.Sha256 = types.StringValue(checksums.sha256Hex)
+	plan.ID = plan.Sha256
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileMoverResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileMoverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksums, err := hashFile(state.Destination.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	if checksums.sha256Hex != state.Sha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *fileMoverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only delete_destination can change without replacing the resource.
+	var plan fileMoverResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileMoverResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileMoverResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DeleteDestination.ValueBool() {
+		return
+	}
+	if err := os.Remove(state.Destination.ValueString()); err != nil && !os.IsNotExist(err) {
+		resp.Diagnostics.AddError("Delete Failed", err.Error())
+	}
+}
+
+// moveFile moves source to destination with rename, falling back to copying
+// and removing source when rename fails because the paths are on different
+// file systems.
+func moveFile(source, destination string, rename func(oldpath, newpath string) error) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", source)
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return err
+	}
+
+	err = rename(source, destination)
+	if err == nil || !errors.Is(err, errCrossDevice) {
+		return err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	err = writeFileAtomic(destination, func(w io.Writer) error {
+		_, err := io.Copy(w, in)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(destination, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	// Windows cannot remove files that are open.
+	in.Close()
+	if err := os.Remove(source); err != nil {
+		return fmt.Errorf("%s was copied to %s, but removing it failed: %w", source, destination, err)
+	}
+	return nil
+}
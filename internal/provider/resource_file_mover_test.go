@@ -0,0 +1,113 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMoverResource(t *testing.T) {
+	want := []byte(testRandString(32))
+	dir := t.TempDir()
+	source := filepath.Join(dir, "staging", "app.tar.gz")
+	destination := filepath.Join(dir, "releases", "app.tar.gz")
+	require.NoError(t, os.MkdirAll(filepath.Dir(source), 0o755))
+	require.NoError(t, os.WriteFile(source, want, 0o644))
+
+	sum := sha256.Sum256(want)
+	sha256Hex := hex.EncodeToString(sum[:])
+
+	config := func(deleteDestination bool) string {
+		return fmt.Sprintf(`
+			resource "utility_file_mover" "release" {
+				source = %q
+				destination = %q
+				delete_destination = %t
+			}`, source, destination, deleteDestination)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_file_mover.release", "sha256", sha256Hex),
+					func(*terraform.State) error {
+						if _, err := os.Stat(source); !os.IsNotExist(err) {
+							return fmt.Errorf("%s was not moved: %v", source, err)
+						}
+						got, err := os.ReadFile(destination)
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, want, got)
+						return nil
+					},
+				),
+			},
+			{
+				// Changing delete_destination is an in-place update.
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("utility_file_mover.release", "sha256", sha256Hex),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(destination); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", destination, err)
+			}
+			return nil
+		},
+	})
+}
+
+func TestMoveFile(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.bin")
+	destination := filepath.Join(dir, "nested", "destination.bin")
+	require.NoError(t, os.WriteFile(source, []byte("renamed"), 0o600))
+
+	require.NoError(t, moveFile(source, destination, os.Rename))
+	assert.NoFileExists(t, source)
+	got, err := os.ReadFile(destination)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", string(got))
+
+	assert.Error(t, moveFile(source, destination, os.Rename))
+	assert.Error(t, moveFile(dir, destination, os.Rename))
+}
+
+func TestMoveFile_CrossDevice(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "source.bin")
+	destination := filepath.Join(dir, "destination.bin")
+	require.NoError(t, os.WriteFile(source, []byte("copied"), 0o600))
+	require.NoError(t, os.WriteFile(destination, []byte("previous"), 0o644))
+
+	crossDevice := func(oldpath, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: errCrossDevice}
+	}
+	require.NoError(t, moveFile(source, destination, crossDevice))
+
+	assert.NoFileExists(t, source)
+	got, err := os.ReadFile(destination)
+	require.NoError(t, err)
+	assert.Equal(t, "copied", string(got))
+	info, err := os.Stat(destination)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*fileTemplateResource)(nil)
+
+type fileTemplateResource struct{}
+
+func NewFileTemplateResource() resource.Resource {
+	return &fileTemplateResource{}
+}
+
+func (r *fileTemplateResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_file_template"
+}
+
+func (r *fileTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that renders a Go `text/template` with variables and writes the result to a file, such as a configuration file for a service. Templates support the same functions as the `utility_template_file` data source. The file is written atomically and recreated if it is removed or changed on disk. Destroying the resource removes the file.",
+		Attributes: map[string]schema.Attribute{
+			"template": schema.StringAttribute{
+				Description: "The template to render.",
+				Required:    true,
+			},
+			"vars": schema.MapAttribute{
+				Description: "Variables available to the template. Referencing a variable that is not set is an error.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"filename": schema.StringAttribute{
+				Description: "Path of the file to write. Missing directories are created, and an existing file is replaced.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_mode": schema.StringAttribute{
+				Description: "Permissions of the file as an octal string, such as \"0600\" for secrets. The mode is set exactly, regardless of the umask. Defaults to \"0644\".",
+				Optional:    true,
+				Validators: []validator.String{
+					fileModeValidator{},
+				},
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the rendered content, checked on refresh to detect changes to the file.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The path of the file.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type fileTemplateResourceModel struct {
+	Template types.String `tfsdk:"template"`
+	Vars     types.Map    `tfsdk:"vars"`
+	Filename types.String `tfsdk:"filename"`
+	FileMode types.String `tfsdk:"file_mode"`
+	Sha256   types.String `tfsdk:"sha256"`
+	ID       types.String `tfsdk:"id"`
+}
+
+func (r *fileTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.write(); err != nil {
+		resp.Diagnostics.AddError("Writing Template Failed", err.Error())
+		return
+	}
+	plan.ID = plan.Filename
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checksums, err := hashFile(state.Filename.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	if checksums.sha256Hex != state.Sha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *fileTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan fileTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.write(); err != nil {
+		resp.Diagnostics.AddError("Writing Template Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *fileTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(state.Filename.ValueString()); err != nil && !os.IsNotExist(err) {
+		resp.Diagnostics.AddError("Delete Failed", err.Error())
+	}
+}
+
+// write renders the template of m into its file and records the checksum of
+// the rendered content.
+func (m *fileTemplateResourceModel) write() error {
+	filename := m.Filename.ValueString()
+	rendered, err := renderTemplate(filepath.Base(filename), []byte(m.Template.ValueString()), stringMapValue(m.Vars))
+	if err != nil {
+		return err
+	}
+
+	mode := os.FileMode(0o644)
+	if !m.FileMode.IsNull() {
+		mode, _ = parseFileMode(m.FileMode.ValueString())
+	}
+	if err := writeFileContent(filename, rendered, mode); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(rendered)
+	m.Sha256 = types.StringValue(hex.EncodeToString(sum[:]))
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTemplateResource(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "conf", "app.conf")
+
+	config := func(region string) string {
+		return fmt.Sprintf(`
+			resource "utility_file_template" "conf" {
+				template = "region = {{ .region | upper }}\n"
+				vars = {
+					region = %q
+				}
+				filename = %q
+			}`, region, filename)
+	}
+
+	checkContent := func(want string) resource.TestCheckFunc {
+		sum := sha256.Sum256([]byte(want))
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("utility_file_template.conf", "sha256", hex.EncodeToString(sum[:])),
+			func(*terraform.State) error {
+				got, err := os.ReadFile(filename)
+				if err != nil {
+					return err
+				}
+				assert.Equal(t, want, string(got))
+				return nil
+			},
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("eu-west-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_file_template.conf", "id", filename),
+					checkContent("region = EU-WEST-1\n"),
+				),
+			},
+			{
+				// Changing vars rewrites the file in place.
+				Config: config("us-east-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_file_template.conf", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkContent("region = US-EAST-1\n"),
+			},
+			{
+				// A file changed on disk is written again.
+				PreConfig: func() {
+					require.NoError(t, os.WriteFile(filename, []byte("region = local-edit\n"), 0o644))
+				},
+				Config: config("us-east-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_file_template.conf", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkContent("region = US-EAST-1\n"),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(filename); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", filename, err)
+			}
+			return nil
+		},
+	})
+}
+
+func TestFileTemplateResource_MissingVariable(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_file_template" "missing" {
+						template = "{{ .missing }}"
+						filename = %q
+					}`, filepath.Join(t.TempDir(), "out.txt")),
+				ExpectError: regexp.MustCompile(`Writing Template Failed`),
+			},
+		},
+	})
+}
+
+func TestFileTemplateResourceModel_Write(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "secret.env")
+	m := fileTemplateResourceModel{
+		Template: types.StringValue("TOKEN={{ .token }}\n"),
+		Vars:     types.MapValueMust(types.StringType, map[string]attr.Value{"token": types.StringValue("s3cr3t")}),
+		Filename: types.StringValue(filename),
+		FileMode: types.StringValue("0600"),
+	}
+	require.NoError(t, m.write())
+
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "TOKEN=s3cr3t\n", string(content))
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), m.Sha256.ValueString())
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(filename)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	// A template that fails to render leaves the existing file alone.
+	m.Template = types.StringValue("{{ .missing }}")
+	require.Error(t, m.write())
+	content, err = os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Equal(t, "TOKEN=s3cr3t\n", string(content))
+}
@@ -0,0 +1,458 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	mirrorIndexFormatJSON      = "json"
+	mirrorIndexFormatSHA256Sum = "sha256sum"
+)
+
+var _ resource.ResourceWithConfigure = (*httpMirrorResource)(nil)
+
+type httpMirrorResource struct {
+	providerData *providerData
+}
+
+func NewHTTPMirrorResource() resource.Resource {
+	return &httpMirrorResource{}
+}
+
+func (r *httpMirrorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *httpMirrorResource) hostLimiter() *hostLimiter {
+	if r.providerData == nil {
+		return nil
+	}
+	return r.providerData.hostLimiter
+}
+
+func (r *httpMirrorResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_http_mirror"
+}
+
+func (r *httpMirrorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that keeps a local directory in sync with a remote index of files and their SHA256 checksums. Files that are missing or changed locally are downloaded and verified, and files that are no longer listed in the index are removed.",
+		Attributes: map[string]schema.Attribute{
+			"index_url": schema.StringAttribute{
+				Description: "The full HTTP or HTTPS URL of the index.",
+				Required:    true,
+			},
+			"index_format": schema.StringAttribute{
+				Description: "Format of the index (default: json). 'json' expects an object mapping relative file paths to SHA256 checksums. 'sha256sum' expects the output of the `sha256sum` command.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mirrorIndexFormatJSON, mirrorIndexFormatSHA256Sum),
+				},
+				Default: stringdefault.StaticString(mirrorIndexFormatJSON),
+			},
+			"base_url": schema.StringAttribute{
+				Description: "URL the relative paths in the index are resolved against. Defaults to the directory of `index_url`.",
+				Optional:    true,
+			},
+			"directory": schema.StringAttribute{
+				Description: "Local directory to mirror into. Files in this directory that are not listed in the index are removed.",
+				Required:    true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in every request. A value can reference an environment variable as `$${env:NAME}`, or `$${env:NAME:-default}` to fall back to `default`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"concurrency": schema.Int64Attribute{
+				Description: "Maximum number of files downloaded at the same time (default: 4).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(4),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"files": schema.MapAttribute{
+				Description: "Map of the mirrored relative file paths to their SHA256 checksums.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"added": schema.ListAttribute{
+				Description: "Relative paths of the files downloaded because they were missing locally during the last sync.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"updated": schema.ListAttribute{
+				Description: "Relative paths of the files downloaded because their local checksum differed from the index during the last sync.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"removed": schema.ListAttribute{
+				Description: "Relative paths of the local files removed because they were not listed in the index during the last sync.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description: "The index URL.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *httpMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan httpMirrorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *httpMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state httpMirrorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	index, err := fetchMirrorIndex(ctx, r.hostLimiter(), state.IndexURL.ValueString(), state.IndexFormat.ValueString(), stringMapValue(state.Headers))
+	if err != nil {
+		resp.Diagnostics.AddError("Index Download Failed", err.Error())
+		return
+	}
+
+	local, err := hashDirectory(state.Directory.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Mirror Read Failed", err.Error())
+		return
+	}
+
+	// Any difference between the index and the directory means the mirror is
+	// out of sync, so let Terraform recreate it.
+	if added, updated, removed := diffMirror(index, local); len(added)+len(updated)+len(removed) > 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *httpMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan httpMirrorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *httpMirrorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state httpMirrorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dir := state.Directory.ValueString()
+	for rel := range state.Files.Elements() {
+		os.Remove(filepath.Join(dir, filepath.FromSlash(rel)))
+	}
+}
+
+type httpMirrorResourceModel struct {
+	IndexURL    types.String `tfsdk:"index_url"`
+	IndexFormat types.String `tfsdk:"index_format"`
+	BaseURL     types.String `tfsdk:"base_url"`
+	Directory   types.String `tfsdk:"directory"`
+	Headers     types.Map    `tfsdk:"headers"`
+	Concurrency types.Int64  `tfsdk:"concurrency"`
+	Files       types.Map    `tfsdk:"files"`
+	Added       types.List   `tfsdk:"added"`
+	Updated     types.List   `tfsdk:"updated"`
+	Removed     types.List   `tfsdk:"removed"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// sync brings the directory in line with the index and records the result
+// in plan.
+func (r *httpMirrorResource) sync(ctx context.Context, plan *httpMirrorResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	headers := stringMapValue(plan.Headers)
+	dir := plan.Directory.ValueString()
+
+	index, err := fetchMirrorIndex(ctx, r.hostLimiter(), plan.IndexURL.ValueString(), plan.IndexFormat.ValueString(), headers)
+	if err != nil {
+		diags.AddError("Index Download Failed", err.Error())
+		return diags
+	}
+
+	baseURL := plan.BaseURL.ValueString()
+	if baseURL == "" {
+		u, err := url.Parse(plan.IndexURL.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Index URL", err.Error())
+			return diags
+		}
+		u.Path = path.Dir(u.Path)
+		u.RawQuery = ""
+		baseURL = u.String()
+	}
+
+	local, err := hashDirectory(dir)
+	if err != nil {
+		diags.AddError("Mirror Sync Failed", err.Error())
+		return diags
+	}
+
+	added, updated, removed := diffMirror(index, local)
+
+	toFetch := append(append([]string{}, added...), updated...)
+	errs := make([]error, len(toFetch))
+	sem := make(chan struct{}, plan.Concurrency.ValueInt64())
+	var wg sync.WaitGroup
+	for i, rel := range toFetch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			errs[i] = r.fetchFile(ctx, baseURL, dir, rel, index[rel], headers)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		diags.AddError("Mirror Sync Failed", err.Error())
+		return diags
+	}
+
+	for _, rel := range removed {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(rel))); err != nil && !os.IsNotExist(err) {
+			diags.AddError("Mirror Sync Failed", err.Error())
+			return diags
+		}
+	}
+
+	files, d := types.MapValueFrom(ctx, types.StringType, index)
+	diags.Append(d...)
+	plan.Files = files
+	plan.Added, d = types.ListValueFrom(ctx, types.StringType, added)
+	diags.Append(d...)
+	plan.Updated, d = types.ListValueFrom(ctx, types.StringType, updated)
+	diags.Append(d...)
+	plan.Removed, d = types.ListValueFrom(ctx, types.StringType, removed)
+	diags.Append(d...)
+	plan.ID = plan.IndexURL
+
+	return diags
+}
+
+// fetchFile downloads a single file listed in the index and verifies its
+// checksum, removing it again on mismatch.
+func (r *httpMirrorResource) fetchFile(ctx context.Context, baseURL, dir, rel, wantSHA256 string, headers map[string]string) error {
+	fileURL, err := url.JoinPath(baseURL, strings.Split(rel, "/")...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rel, err)
+	}
+
+	dest := filepath.Join(dir, filepath.FromSlash(rel))
+	result, err := downloadFile(ctx, r.hostLimiter(), downloadOptions{
+		method:  http.MethodGet,
+		url:     fileURL,
+		path:    dest,
+		headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", rel, err)
+	}
+
+	if result.sha256Hex != wantSHA256 {
+		os.Remove(dest)
+		return fmt.Errorf("%s: checksum mismatch: got %s, index lists %s", rel, result.sha256Hex, wantSHA256)
+	}
+
+	return nil
+}
+
+// mirrorIndexMaxBytes bounds the size of a mirror index, which lists a line
+// per mirrored file.
+const mirrorIndexMaxBytes = 16 << 20
+
+// mirrorIndexTimeout bounds the time spent downloading a mirror index.
+const mirrorIndexTimeout = 5 * time.Minute
+
+// fetchMirrorIndex downloads and parses the index at indexURL.
+func fetchMirrorIndex(ctx context.Context, limiter *hostLimiter, indexURL, format string, headers map[string]string) (map[string]string, error) {
+	u, err := url.Parse(indexURL)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := limiter.acquire(ctx, u.Host)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := fetchResponse(ctx, downloadOptions{
+		method:  http.MethodGet,
+		url:     indexURL,
+		headers: headers,
+		timeout: mirrorIndexTimeout,
+	}, mirrorIndexMaxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.status != http.StatusOK {
+		return nil, fmt.Errorf("failed to download index: %d %s", resp.status, http.StatusText(resp.status))
+	}
+
+	return parseMirrorIndex(format, resp.body)
+}
+
+// parseMirrorIndex parses an index into a map of slash-separated relative
+// paths to lowercase SHA256 checksums.
+func parseMirrorIndex(format string, data []byte) (map[string]string, error) {
+	index := make(map[string]string)
+
+	switch format {
+	case mirrorIndexFormatJSON:
+		if err := json.Unmarshal(data, &index); err != nil {
+			return nil, fmt.Errorf("invalid JSON index: %w", err)
+		}
+	case mirrorIndexFormatSHA256Sum:
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+			sum, name, ok := strings.Cut(text, " ")
+			if !ok {
+				return nil, fmt.Errorf("invalid sha256sum index: line %d: missing file name", line)
+			}
+			// A leading "*" marks binary mode in sha256sum output.
+			index[strings.TrimPrefix(strings.TrimSpace(name), "*")] = sum
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported index format %q", format)
+	}
+
+	cleaned := make(map[string]string, len(index))
+	for rel, sum := range index {
+		clean := path.Clean(strings.TrimPrefix(rel, "./"))
+		if !fs.ValidPath(clean) || clean == "." {
+			return nil, fmt.Errorf("invalid path %q in index: paths must be relative and stay within the directory", rel)
+		}
+		if !isHexChecksum(sum, sha256HexLength) {
+			return nil, fmt.Errorf("invalid SHA256 checksum %q for %q in index", sum, rel)
+		}
+		cleaned[clean] = strings.ToLower(sum)
+	}
+
+	return cleaned, nil
+}
+
+// hashDirectory returns the SHA256 checksum of every regular file below dir,
+// keyed by slash-separated relative path, as hashTree does with symlinks
+// skipped. A missing directory is empty.
+func hashDirectory(dir string) (map[string]string, error) {
+	if _, err := os.Lstat(dir); errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+
+	tree, err := hashTree(dir, symlinkPolicySkip)
+	if err != nil {
+		return nil, err
+	}
+	return tree.files, nil
+}
+
+// diffMirror compares the index with the local checksums and returns the
+// sorted relative paths to add, update and remove.
+func diffMirror(index, local map[string]string) (added, updated, removed []string) {
+	added, updated, removed = []string{}, []string{}, []string{}
+
+	for rel, sum := range index {
+		localSum, ok := local[rel]
+		switch {
+		case !ok:
+			added = append(added, rel)
+		case localSum != sum:
+			updated = append(updated, rel)
+		}
+	}
+	for rel := range local {
+		if _, ok := index[rel]; !ok {
+			removed = append(removed, rel)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(updated)
+	sort.Strings(removed)
+
+	return added, updated, removed
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPMirrorResource(t *testing.T) {
+	files := map[string][]byte{
+		"a.txt":     []byte(testRandString(32)),
+		"sub/b.txt": []byte(testRandString(32)),
+	}
+	index := make(map[string]string)
+	for name, content := range files {
+		sum := sha256.Sum256(content)
+		index[name] = hex.EncodeToString(sum[:])
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dist/index.json" {
+			_ = json.NewEncoder(w).Encode(index)
+			return
+		}
+		content, ok := files[r.URL.Path[len("/dist/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "stale.txt")
+	require.NoError(t, os.WriteFile(stale, []byte("stale"), 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_http_mirror" "mirror" {
+						index_url = "%s/dist/index.json"
+						directory = %q
+					}`, ts.URL, dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_http_mirror.mirror", "files.a.txt", index["a.txt"]),
+					resource.TestCheckResourceAttr("utility_http_mirror.mirror", "files.sub/b.txt", index["sub/b.txt"]),
+					resource.TestCheckResourceAttr("utility_http_mirror.mirror", "added.#", "2"),
+					resource.TestCheckResourceAttr("utility_http_mirror.mirror", "updated.#", "0"),
+					resource.TestCheckResourceAttr("utility_http_mirror.mirror", "removed.0", "stale.txt"),
+					func(_ *terraform.State) error {
+						got, err := os.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, files["sub/b.txt"], got)
+						assert.NoFileExists(t, stale)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestFetchMirrorIndex(t *testing.T) {
+	sum := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.txt":
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			fmt.Fprintf(w, "%s  a.txt\n", sum)
+		case "/large.txt":
+			w.Write(bytes.Repeat([]byte("#"), mirrorIndexMaxBytes+1))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	index, err := fetchMirrorIndex(context.Background(), nil, ts.URL+"/index.txt", mirrorIndexFormatSHA256Sum, map[string]string{"Authorization": "Bearer token"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a.txt": sum}, index)
+
+	_, err = fetchMirrorIndex(context.Background(), nil, ts.URL+"/large.txt", mirrorIndexFormatSHA256Sum, nil)
+	assert.ErrorContains(t, err, "larger than")
+
+	_, err = fetchMirrorIndex(context.Background(), nil, ts.URL+"/missing.txt", mirrorIndexFormatSHA256Sum, nil)
+	assert.ErrorContains(t, err, "failed to download index: 404 Not Found")
+}
+
+func TestParseMirrorIndex(t *testing.T) {
+	sum := "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+
+	index, err := parseMirrorIndex(mirrorIndexFormatSHA256Sum, []byte(
+		"# generated\n"+sum+"  ./a.txt\n"+sum+" *dir/b.bin\n",
+	))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"a.txt":     "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		"dir/b.bin": "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}, index)
+
+	_, err = parseMirrorIndex(mirrorIndexFormatJSON, []byte(`{"../escape.txt": "`+sum+`"}`))
+	assert.ErrorContains(t, err, "invalid path")
+
+	_, err = parseMirrorIndex(mirrorIndexFormatJSON, []byte(`{"a.txt": "abc"}`))
+	assert.ErrorContains(t, err, "invalid SHA256 checksum")
+}
+
+func TestDiffMirror(t *testing.T) {
+	added, updated, removed := diffMirror(
+		map[string]string{"new": "1", "same": "2", "changed": "3"},
+		map[string]string{"same": "2", "changed": "4", "old": "5"},
+	)
+	assert.Equal(t, []string{"new"}, added)
+	assert.Equal(t, []string{"changed"}, updated)
+	assert.Equal(t, []string{"old"}, removed)
+}
+
+func TestHashDirectory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b.txt"), []byte("b"), 0o644))
+
+	sums, err := hashDirectory(dir)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("b"))
+	assert.Equal(t, map[string]string{"sub/b.txt": hex.EncodeToString(sum[:])}, sums)
+
+	// Symlinks are not part of the mirror.
+	if err := os.Symlink("sub/b.txt", filepath.Join(dir, "link.txt")); err == nil {
+		sums, err = hashDirectory(dir)
+		require.NoError(t, err)
+		assert.Len(t, sums, 1)
+	}
+
+	sums, err = hashDirectory(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, sums)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*localFileResource)(nil)
+
+type localFileResource struct{}
+
+func NewLocalFileResource() resource.Resource {
+	return &localFileResource{}
+}
+
+func (r *localFileResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_local_file"
+}
+
+func (r *localFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that writes content given in the configuration to a file, such as a generated configuration or a certificate. The file is written atomically and recreated if it is removed or changed on disk. Destroying the resource removes the file.",
+		Attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				Description: "Content to write, as UTF-8 text. Exactly one of `content` and `content_base64` is required.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content_base64")),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "Content to write, standard base64 encoded, for binary files such as the output of `filebase64()`.",
+				Optional:    true,
+				Validators: []validator.String{
+					base64Validator{},
+				},
+			},
+			"filename": schema.StringAttribute{
+				Description: "Path of the file to write. Missing directories are created, and an existing file is replaced.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_mode": schema.StringAttribute{
+				Description: "Permissions of the file as an octal string, such as \"0600\" for secrets. The mode is set exactly, regardless of the umask. Defaults to \"0644\".",
+				Optional:    true,
+				Validators: []validator.String{
+					fileModeValidator{},
+				},
+			},
+			"sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the content, checked on refresh to detect changes to the file.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The path of the file.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type localFileResourceModel struct {
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	Filename      types.String `tfsdk:"filename"`
+	FileMode      types.String `tfsdk:"file_mode"`
+	Sha256        types.String `tfsdk:"sha256"`
+	ID            types.String `tfsdk:"id"`
+}
+
+func (r *localFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan localFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.write(); err != nil {
+		resp.Diagnostics.AddError("Writing File Failed", err.Error())
+		return
+	}
+	plan.ID = plan.Filename
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *localFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state localFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unchanged, err := state.unchanged()
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	if !unchanged {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *localFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan localFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.write(); err != nil {
+		resp.Diagnostics.AddError("Writing File Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *localFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state localFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := os.Remove(state.Filename.ValueString()); err != nil && !os.IsNotExist(err) {
+		resp.Diagnostics.AddError("Delete Failed", err.Error())
+	}
+}
+
+// content returns the bytes to write, decoding content_base64 if set.
+func (m *localFileResourceModel) content() ([]byte, error) {
+	if !m.ContentBase64.IsNull() {
+		return base64.StdEncoding.DecodeString(m.ContentBase64.ValueString())
+	}
+	return []byte(m.Content.ValueString()), nil
+}
+
+// write writes the content of m to its file and records its checksum.
+func (m *localFileResourceModel) write() error {
+	content, err := m.content()
+	if err != nil {
+		return err
+	}
+
+	mode := os.FileMode(0o644)
+	if !m.FileMode.IsNull() {
+		mode, _ = parseFileMode(m.FileMode.ValueString())
+	}
+	if err := writeFileContent(m.Filename.ValueString(), content, mode); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(content)
+	m.Sha256 = types.StringValue(hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// unchanged reports whether the file of m still exists with the checksum
+// recorded when it was written.
+func (m *localFileResourceModel) unchanged() (bool, error) {
+	checksums, err := hashFile(m.Filename.ValueString())
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return checksums.sha256Hex == m.Sha256.ValueString(), nil
+}
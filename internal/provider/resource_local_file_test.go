@@ -0,0 +1,222 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkLocalFile checks that the file of the utility_local_file resource
+// name holds want and that its sha256 attribute matches.
+func checkLocalFile(t *testing.T, name, filename string, want []byte) resource.TestCheckFunc {
+	sum := sha256.Sum256(want)
+	return resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(name, "sha256", hex.EncodeToString(sum[:])),
+		func(*terraform.State) error {
+			got, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, want, got)
+			return nil
+		},
+	)
+}
+
+func TestLocalFileResource(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "conf", "greeting.txt")
+
+	config := func(content string) string {
+		return fmt.Sprintf(`
+			resource "utility_local_file" "text" {
+				content  = %q
+				filename = %q
+			}`, content, filename)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Grüße, 世界 👋\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_local_file.text", "id", filename),
+					checkLocalFile(t, "utility_local_file.text", filename, []byte("Grüße, 世界 👋\n")),
+				),
+			},
+			{
+				// Changing the content rewrites the file in place.
+				Config: config("hello\n"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_local_file.text", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkLocalFile(t, "utility_local_file.text", filename, []byte("hello\n")),
+			},
+			{
+				// A file changed on disk is written again.
+				PreConfig: func() {
+					require.NoError(t, os.WriteFile(filename, []byte("local edit\n"), 0o644))
+				},
+				Config: config("hello\n"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_local_file.text", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkLocalFile(t, "utility_local_file.text", filename, []byte("hello\n")),
+			},
+			{
+				// So is a removed file.
+				PreConfig: func() {
+					require.NoError(t, os.Remove(filename))
+				},
+				Config: config("hello\n"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_local_file.text", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkLocalFile(t, "utility_local_file.text", filename, []byte("hello\n")),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(filename); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", filename, err)
+			}
+			return nil
+		},
+	})
+}
+
+func TestLocalFileResource_Base64(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "blob.bin")
+	content := []byte{0x00, 0xff, 0x1f, 0x8b, 0x80, '\n'}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_local_file" "blob" {
+						content_base64 = %q
+						filename       = %q
+						file_mode      = "0600"
+					}`, base64.StdEncoding.EncodeToString(content), filename),
+				Check: checkLocalFile(t, "utility_local_file.blob", filename, content),
+			},
+		},
+	})
+}
+
+func TestLocalFileResource_InvalidConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "out.txt")
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_local_file" "both" {
+						content        = "text"
+						content_base64 = "dGV4dA=="
+						filename       = %q
+					}`, filename),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_local_file" "invalid" {
+						content_base64 = "not base64!"
+						filename       = %q
+					}`, filename),
+				ExpectError: regexp.MustCompile(`value must be standard base64 encoded`),
+			},
+		},
+	})
+}
+
+func TestLocalFileResourceModel_Write(t *testing.T) {
+	dir := t.TempDir()
+
+	text := localFileResourceModel{
+		Content:       types.StringValue("naïve café ☕\n"),
+		ContentBase64: types.StringNull(),
+		Filename:      types.StringValue(filepath.Join(dir, "nested", "text.txt")),
+		FileMode:      types.StringNull(),
+	}
+	require.NoError(t, text.write())
+	content, err := os.ReadFile(text.Filename.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, "naïve café ☕\n", string(content))
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), text.Sha256.ValueString())
+
+	binary := []byte{0xde, 0xad, 0xbe, 0xef, 0x00}
+	blob := localFileResourceModel{
+		Content:       types.StringNull(),
+		ContentBase64: types.StringValue(base64.StdEncoding.EncodeToString(binary)),
+		Filename:      types.StringValue(filepath.Join(dir, "blob.bin")),
+		FileMode:      types.StringValue("0600"),
+	}
+	require.NoError(t, blob.write())
+	content, err = os.ReadFile(blob.Filename.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, binary, content)
+	sum = sha256.Sum256(binary)
+	assert.Equal(t, hex.EncodeToString(sum[:]), blob.Sha256.ValueString())
+
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(blob.Filename.ValueString())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	// Invalid base64 leaves the existing file alone.
+	blob.ContentBase64 = types.StringValue("not base64!")
+	require.Error(t, blob.write())
+	content, err = os.ReadFile(blob.Filename.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, binary, content)
+}
+
+func TestLocalFileResourceModel_Unchanged(t *testing.T) {
+	m := localFileResourceModel{
+		Content:       types.StringValue("original\n"),
+		ContentBase64: types.StringNull(),
+		Filename:      types.StringValue(filepath.Join(t.TempDir(), "drift.txt")),
+		FileMode:      types.StringNull(),
+	}
+	require.NoError(t, m.write())
+
+	unchanged, err := m.unchanged()
+	require.NoError(t, err)
+	assert.True(t, unchanged)
+
+	require.NoError(t, os.WriteFile(m.Filename.ValueString(), []byte("modified\n"), 0o644))
+	unchanged, err = m.unchanged()
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+
+	require.NoError(t, os.Remove(m.Filename.ValueString()))
+	unchanged, err = m.unchanged()
+	require.NoError(t, err)
+	assert.False(t, unchanged)
+}
@@ -0,0 +1,340 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordLower   = "abcdefghijklmnopqrstuvwxyz"
+	passwordNumeric = "0123456789"
+	passwordSpecial = "!@#$%&*()-_=+[]{}<>:?"
+
+	// bcryptMaxPasswordLength is the number of bytes bcrypt accepts.
+	bcryptMaxPasswordLength = 72
+)
+
+var _ resource.ResourceWithValidateConfig = (*randomPasswordResource)(nil)
+
+type randomPasswordResource struct{}
+
+func NewRandomPasswordResource() resource.Resource {
+	return &randomPasswordResource{}
+}
+
+func (r *randomPasswordResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_random_password"
+}
+
+func (r *randomPasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	classAttribute := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(true),
+			PlanModifiers: []planmodifier.Bool{
+				boolplanmodifier.RequiresReplace(),
+			},
+		}
+	}
+	minAttribute := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Computed:    true,
+			Default:     int64default.StaticInt64(0),
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.RequiresReplace(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Resource to generate a random password with `crypto/rand`. A minimum number of characters can be guaranteed for each character class. The password is generated once and kept until an input or one of the `keepers` changes.",
+		Attributes: map[string]schema.Attribute{
+			"length": schema.Int64Attribute{
+				Description: "Length of the password. Must be at least the sum of the `min_*` attributes.",
+				Required:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"upper":   classAttribute("Include uppercase letters (default: true)."),
+			"lower":   classAttribute("Include lowercase letters (default: true)."),
+			"numeric": classAttribute("Include digits (default: true)."),
+			"special": classAttribute("Include special characters (default: true)."),
+			"override_special": schema.StringAttribute{
+				Description: "Special characters to use instead of the default set `" + passwordSpecial + "`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(passwordSpecial),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"min_upper":   minAttribute("Minimum number of uppercase letters (default: 0)."),
+			"min_lower":   minAttribute("Minimum number of lowercase letters (default: 0)."),
+			"min_numeric": minAttribute("Minimum number of digits (default: 0)."),
+			"min_special": minAttribute("Minimum number of special characters (default: 0)."),
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger the generation of a new password.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"result": schema.StringAttribute{
+				Description: "The generated password.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bcrypt_hash": schema.StringAttribute{
+				Description: "bcrypt hash of the generated password, with the default cost. Null when the password is longer than the 72 bytes bcrypt accepts.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "A static value used internally by Terraform, this should not be referenced in configurations.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *randomPasswordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config randomPasswordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var sum int64
+	for _, class := range config.classes() {
+		if class.enabled.IsUnknown() || class.min.IsUnknown() {
+			return
+		}
+		if !class.enabled.IsNull() && !class.enabled.ValueBool() && class.min.ValueInt64() > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("min_"+class.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("min_%s cannot be greater than zero when %s is false.", class.name, class.name),
+			)
+		}
+		sum += class.min.ValueInt64()
+	}
+
+	if !config.Length.IsNull() && !config.Length.IsUnknown() && config.Length.ValueInt64() < sum {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("length"),
+			"Invalid Attribute Value",
+			fmt.Sprintf("length (%d) must be at least the sum of the min_* attributes (%d).", config.Length.ValueInt64(), sum),
+		)
+	}
+}
+
+func (r *randomPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan randomPasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := generatePassword(int(plan.Length.ValueInt64()), plan.passwordClasses())
+	if err != nil {
+		resp.Diagnostics.AddError("Password Generation Failed", err.Error())
+		return
+	}
+
+	plan.Result = types.StringValue(password)
+	plan.BcryptHash = types.StringNull()
+	if len(password) <= bcryptMaxPasswordLength {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			resp.Diagnostics.AddError("Password Hashing Failed", err.Error())
+			return
+		}
+		plan.BcryptHash = types.StringValue(string(hash))
+	}
+	plan.ID = types.StringValue("none")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *randomPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called with changes, as every argument requires
+// replacement.
+func (r *randomPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan randomPasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *randomPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+type randomPasswordResourceModel struct {
+	Length          types.Int64  `tfsdk:"length"`
+	Upper           types.Bool   `tfsdk:"upper"`
+	Lower           types.Bool   `tfsdk:"lower"`
+	Numeric         types.Bool   `tfsdk:"numeric"`
+	Special         types.Bool   `tfsdk:"special"`
+	OverrideSpecial types.String `tfsdk:"override_special"`
+	MinUpper        types.Int64  `tfsdk:"min_upper"`
+	MinLower        types.Int64  `tfsdk:"min_lower"`
+	MinNumeric      types.Int64  `tfsdk:"min_numeric"`
+	MinSpecial      types.Int64  `tfsdk:"min_special"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	Result          types.String `tfsdk:"result"`
+	BcryptHash      types.String `tfsdk:"bcrypt_hash"`
+	ID              types.String `tfsdk:"id"`
+}
+
+type passwordClassConfig struct {
+	name    string
+	enabled types.Bool
+	min     types.Int64
+}
+
+func (m *randomPasswordResourceModel) classes() []passwordClassConfig {
+	return []passwordClassConfig{
+		{"upper", m.Upper, m.MinUpper},
+		{"lower", m.Lower, m.MinLower},
+		{"numeric", m.Numeric, m.MinNumeric},
+		{"special", m.Special, m.MinSpecial},
+	}
+}
+
+// passwordClasses returns the enabled character classes.
+func (m *randomPasswordResourceModel) passwordClasses() []passwordClass {
+	charsets := map[string]string{
+		"upper":   passwordUpper,
+		"lower":   passwordLower,
+		"numeric": passwordNumeric,
+		"special": m.OverrideSpecial.ValueString(),
+	}
+
+	var classes []passwordClass
+	for _, class := range m.classes() {
+		if class.enabled.ValueBool() {
+			classes = append(classes, passwordClass{
+				charset: charsets[class.name],
+				min:     int(class.min.ValueInt64()),
+			})
+		}
+	}
+	return classes
+}
+
+// passwordClass is a set of characters of which a password must contain at
+// least min.
+type passwordClass struct {
+	charset string
+	min     int
+}
+
+// generatePassword returns a password of the given length drawn uniformly
+// from the union of the classes, with at least min characters of each class.
+func generatePassword(length int, classes []passwordClass) (string, error) {
+	var all string
+	var required int
+	// The union may repeat characters shared by classes, which only skews
+	// the distribution towards them slightly.
+	for _, class := range classes {
+		all += class.charset
+		required += class.min
+	}
+	if all == "" {
+		return "", errors.New("at least one character class must be enabled")
+	}
+	if required > length {
+		return "", fmt.Errorf("length %d is less than the %d required characters", length, required)
+	}
+
+	password := make([]rune, 0, length)
+	for _, class := range classes {
+		for range class.min {
+			c, err := randomChar(class.charset)
+			if err != nil {
+				return "", err
+			}
+			password = append(password, c)
+		}
+	}
+	for len(password) < length {
+		c, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, c)
+	}
+
+	// Shuffle so the guaranteed characters are not always at the start.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (rune, error) {
+	chars := []rune(charset)
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i.Int64()], nil
+}
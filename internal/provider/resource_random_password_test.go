@@ -0,0 +1,126 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestRandomPasswordResource(t *testing.T) {
+	var first string
+
+	// The minimums are well above what a random password of this length
+	// usually has, so they are only met if they are enforced.
+	config := func(rotation string) string {
+		return fmt.Sprintf(`
+			resource "utility_random_password" "password" {
+				length = 20
+				min_upper = 4
+				min_lower = 4
+				min_numeric = 5
+				min_special = 3
+				keepers = {
+					rotation = %q
+				}
+			}`, rotation)
+	}
+	checkMinimums := func(password string) {
+		assert.GreaterOrEqual(t, countRunesIn(password, passwordUpper), 4, password)
+		assert.GreaterOrEqual(t, countRunesIn(password, passwordLower), 4, password)
+		assert.GreaterOrEqual(t, countRunesIn(password, passwordNumeric), 5, password)
+		assert.GreaterOrEqual(t, countRunesIn(password, passwordSpecial), 3, password)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check: func(s *terraform.State) error {
+					attrs := s.RootModule().Resources["utility_random_password.password"].Primary.Attributes
+					first = attrs["result"]
+					assert.Len(t, first, 20)
+					checkMinimums(first)
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(attrs["bcrypt_hash"]), []byte(first)))
+					return nil
+				},
+			},
+			{
+				// Refreshing and planning the same configuration keeps the
+				// password.
+				Config:   config("1"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("1"),
+				Check: resource.TestCheckResourceAttrWith("utility_random_password.password", "result", func(value string) error {
+					assert.Equal(t, first, value)
+					return nil
+				}),
+			},
+			{
+				Config: config("2"),
+				Check: resource.TestCheckResourceAttrWith("utility_random_password.password", "result", func(value string) error {
+					assert.NotEqual(t, first, value)
+					checkMinimums(value)
+					return nil
+				}),
+			},
+			{
+				Config: `
+					resource "utility_random_password" "password" {
+						length = 2
+						min_numeric = 2
+						min_special = 1
+					}`,
+				ExpectError: regexp.MustCompile(`must be at least the sum of the min_\* attributes`),
+			},
+		},
+	})
+}
+
+func TestGeneratePassword(t *testing.T) {
+	classes := []passwordClass{
+		{charset: passwordUpper, min: 3},
+		{charset: passwordNumeric, min: 4},
+		{charset: "€$", min: 2},
+	}
+
+	for range 100 {
+		password, err := generatePassword(10, classes)
+		require.NoError(t, err)
+
+		assert.Equal(t, 10, utf8.RuneCountInString(password))
+		for _, class := range classes {
+			assert.GreaterOrEqual(t, countRunesIn(password, class.charset), class.min, password)
+		}
+	}
+
+	_, err := generatePassword(8, classes)
+	assert.ErrorContains(t, err, "less than the 9 required characters")
+
+	_, err = generatePassword(8, nil)
+	assert.ErrorContains(t, err, "at least one character class")
+}
+
+// countRunesIn returns the number of characters of s that are in charset.
+func countRunesIn(s, charset string) int {
+	count := 0
+	for _, c := range s {
+		if strings.ContainsRune(charset, c) {
+			count++
+		}
+	}
+	return count
+}
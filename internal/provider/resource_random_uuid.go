@@ -0,0 +1,241 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// uuidNamespaces are the predefined namespaces of RFC 4122 for version 5
+// UUIDs.
+var uuidNamespaces = map[string]string{
+	"dns":  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	"url":  "6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	"oid":  "6ba7b812-9dad-11d1-80b4-00c04fd430c8",
+	"x500": "6ba7b814-9dad-11d1-80b4-00c04fd430c8",
+}
+
+var _ resource.ResourceWithValidateConfig = (*randomUUIDResource)(nil)
+
+type randomUUIDResource struct{}
+
+func NewRandomUUIDResource() resource.Resource {
+	return &randomUUIDResource{}
+}
+
+func (r *randomUUIDResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_random_uuid"
+}
+
+func (r *randomUUIDResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to generate a UUID. Version 4 UUIDs are random, generated with `crypto/rand`; version 5 UUIDs are derived from `namespace` and `name`, so the same inputs always give the same UUID. The UUID is generated once and kept until an input or one of the `keepers` changes.",
+		Attributes: map[string]schema.Attribute{
+			"version": schema.Int64Attribute{
+				Description: "UUID version to generate: 4 for a random UUID or 5 for a name-based one (default: 4).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(4),
+				Validators: []validator.Int64{
+					int64validator.OneOf(4, 5),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Namespace of a version 5 UUID: a UUID, or one of the predefined namespaces `dns`, `url`, `oid` and `x500`. Required with version 5.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of a version 5 UUID within `namespace`. Required with version 5.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will trigger the generation of a new UUID.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"result": schema.StringAttribute{
+				Description: "The generated UUID, in its lowercase canonical form.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The generated UUID, same as `result`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *randomUUIDResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config randomUUIDResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Version.IsUnknown() {
+		return
+	}
+
+	nameBased := config.Version.ValueInt64() == 5
+	for _, attr := range []struct {
+		name  string
+		value types.String
+	}{
+		{"namespace", config.Namespace},
+		{"name", config.Name},
+	} {
+		switch {
+		case nameBased && attr.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				"Missing Attribute Configuration",
+				fmt.Sprintf("%s is required when version is 5.", attr.name),
+			)
+		case !nameBased && !attr.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s can only be set when version is 5.", attr.name),
+			)
+		}
+	}
+
+	if !config.Namespace.IsNull() && !config.Namespace.IsUnknown() {
+		if _, err := uuidNamespace(config.Namespace.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("namespace"), "Invalid Attribute Value", err.Error())
+		}
+	}
+}
+
+func (r *randomUUIDResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan randomUUIDResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var id string
+	if plan.Version.ValueInt64() == 5 {
+		namespace, err := uuidNamespace(plan.Namespace.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("namespace"), "Invalid Attribute Value", err.Error())
+			return
+		}
+		id = uuidV5(namespace, plan.Name.ValueString())
+	} else {
+		var err error
+		id, err = uuidV4()
+		if err != nil {
+			resp.Diagnostics.AddError("UUID Generation Failed", err.Error())
+			return
+		}
+	}
+
+	plan.Result = types.StringValue(id)
+	plan.ID = types.StringValue(id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *randomUUIDResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update is never called with changes, as every argument requires
+// replacement.
+func (r *randomUUIDResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan randomUUIDResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *randomUUIDResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+type randomUUIDResourceModel struct {
+	Version   types.Int64  `tfsdk:"version"`
+	Namespace types.String `tfsdk:"namespace"`
+	Name      types.String `tfsdk:"name"`
+	Keepers   types.Map    `tfsdk:"keepers"`
+	Result    types.String `tfsdk:"result"`
+	ID        types.String `tfsdk:"id"`
+}
+
+// uuidV4 returns a random version 4 UUID.
+func uuidV4() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	return formatUUID(u, 4), nil
+}
+
+// uuidV5 returns the version 5 UUID of name in namespace, as described in
+// RFC 4122 section 4.3.
+func uuidV5(namespace [16]byte, name string) string {
+	sum := sha1.Sum(append(namespace[:], name...))
+	var u [16]byte
+	copy(u[:], sum[:])
+	return formatUUID(u, 5)
+}
+
+// formatUUID sets the version and the RFC 4122 variant bits of u and
+// returns its canonical form.
+func formatUUID(u [16]byte, version byte) string {
+	u[6] = u[6]&0x0f | version<<4
+	u[8] = u[8]&0x3f | 0x80
+
+	s := hex.EncodeToString(u[:])
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// uuidNamespace returns the bytes of the namespace s, which is either the
+// name of a predefined namespace or a UUID.
+func uuidNamespace(s string) ([16]byte, error) {
+	var u [16]byte
+	if predefined, ok := uuidNamespaces[strings.ToLower(s)]; ok {
+		s = predefined
+	}
+
+	b, err := hex.DecodeString(strings.ReplaceAll(s, "-", ""))
+	if err != nil || len(b) != len(u) || len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("namespace %q must be a UUID or one of dns, url, oid and x500", s)
+	}
+	copy(u[:], b)
+	return u, nil
+}
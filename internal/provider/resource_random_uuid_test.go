@@ -0,0 +1,147 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestRandomUUIDResource(t *testing.T) {
+	var first string
+
+	config := func(rotation string) string {
+		return `
+			resource "utility_random_uuid" "id" {
+				keepers = {
+					rotation = "` + rotation + `"
+				}
+			}`
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("utility_random_uuid.id", "result", func(value string) error {
+						first = value
+						assert.Regexp(t, uuidV4Pattern, value)
+						return nil
+					}),
+					resource.TestCheckResourceAttrPair("utility_random_uuid.id", "id", "utility_random_uuid.id", "result"),
+					resource.TestCheckResourceAttr("utility_random_uuid.id", "version", "4"),
+				),
+			},
+			{
+				// Refreshing and planning the same configuration keeps the
+				// UUID.
+				Config:   config("1"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("1"),
+				Check: resource.TestCheckResourceAttrWith("utility_random_uuid.id", "result", func(value string) error {
+					assert.Equal(t, first, value)
+					return nil
+				}),
+			},
+			{
+				Config: config("2"),
+				Check: resource.TestCheckResourceAttrWith("utility_random_uuid.id", "result", func(value string) error {
+					assert.NotEqual(t, first, value)
+					assert.Regexp(t, uuidV4Pattern, value)
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+func TestRandomUUIDResource_Version5(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "utility_random_uuid" "dns" {
+						version   = 5
+						namespace = "dns"
+						name      = "python.org"
+					}
+
+					resource "utility_random_uuid" "custom" {
+						version   = 5
+						namespace = "6BA7B810-9DAD-11D1-80B4-00C04FD430C8"
+						name      = "python.org"
+					}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_random_uuid.dns", "result", "886313e1-3b8a-5372-9b90-0c9aee199e5d"),
+					resource.TestCheckResourceAttr("utility_random_uuid.custom", "result", "886313e1-3b8a-5372-9b90-0c9aee199e5d"),
+				),
+			},
+			{
+				Config: `
+					resource "utility_random_uuid" "dns" {
+						version = 5
+						name    = "python.org"
+					}`,
+				ExpectError: regexp.MustCompile(`namespace is required when version is 5`),
+			},
+			{
+				Config: `
+					resource "utility_random_uuid" "dns" {
+						name = "python.org"
+					}`,
+				ExpectError: regexp.MustCompile(`name can only be set when version is 5`),
+			},
+			{
+				Config: `
+					resource "utility_random_uuid" "dns" {
+						version   = 5
+						namespace = "example.com"
+						name      = "python.org"
+					}`,
+				ExpectError: regexp.MustCompile(`must be a UUID or one of dns, url, oid and x500`),
+			},
+		},
+	})
+}
+
+func TestUUIDV4(t *testing.T) {
+	seen := map[string]bool{}
+	for range 100 {
+		id, err := uuidV4()
+		require.NoError(t, err)
+		assert.Regexp(t, uuidV4Pattern, id)
+		assert.False(t, seen[id], id)
+		seen[id] = true
+	}
+}
+
+func TestUUIDV5(t *testing.T) {
+	for _, tc := range []struct {
+		namespace, name, want string
+	}{
+		{"dns", "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{"url", "http://python.org/", "4c565f0d-3f5a-5890-b41b-20cf47701c5e"},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+	} {
+		namespace, err := uuidNamespace(tc.namespace)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, uuidV5(namespace, tc.name), tc.name)
+	}
+
+	for _, invalid := range []string{"", "example.com", "6ba7b8109dad11d180b400c04fd430c8", "6ba7b810-9dad-11d1-80b4-00c04fd430c", "6ba7b8109-dad-11d1-80b4-00c04fd430c8"} {
+		_, err := uuidNamespace(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*sleepResource)(nil)
+
+type sleepResource struct{}
+
+func NewSleepResource() resource.Resource {
+	return &sleepResource{}
+}
+
+func (r *sleepResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_sleep"
+}
+
+func (r *sleepResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that waits for a fixed time when it is created or destroyed. Use it to give a remote system time to become consistent before dependent resources use it. Interrupting Terraform aborts the wait.",
+		Attributes: map[string]schema.Attribute{
+			"create_duration": schema.StringAttribute{
+				Description: "Time to wait when the resource is created, as a duration such as \"30s\". Changing it does not wait again.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"destroy_duration": schema.StringAttribute{
+				Description: "Time to wait when the resource is destroyed, as a duration such as \"30s\".",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values that, when changed, will recreate the resource, waiting again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "Time the resource was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *sleepResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan sleepResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := sleepFor(ctx, plan.CreateDuration); err != nil {
+		resp.Diagnostics.AddError("Sleep Interrupted", fmt.Sprintf("Waiting %s on create: %s", plan.CreateDuration.ValueString(), err))
+		return
+	}
+	plan.ID = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *sleepResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *sleepResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan sleepResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *sleepResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state sleepResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := sleepFor(ctx, state.DestroyDuration); err != nil {
+		resp.Diagnostics.AddError("Sleep Interrupted", fmt.Sprintf("Waiting %s on destroy: %s", state.DestroyDuration.ValueString(), err))
+	}
+}
+
+type sleepResourceModel struct {
+	CreateDuration  types.String `tfsdk:"create_duration"`
+	DestroyDuration types.String `tfsdk:"destroy_duration"`
+	Triggers        types.Map    `tfsdk:"triggers"`
+	ID              types.String `tfsdk:"id"`
+}
+
+// sleepFor waits for the duration held by d, which may be null, or until ctx
+// is done.
+func sleepFor(ctx context.Context, d types.String) error {
+	if d.IsNull() {
+		return nil
+	}
+	duration, err := time.ParseDuration(d.ValueString())
+	if err != nil {
+		return err
+	}
+	return sleepContext(ctx, duration)
+}
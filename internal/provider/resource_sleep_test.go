@@ -0,0 +1,71 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSleepResource(t *testing.T) {
+	var started time.Time
+	checkSlept := func(*terraform.State) error {
+		if elapsed := time.Since(started); elapsed < 200*time.Millisecond {
+			return fmt.Errorf("created after %s, want at least 200ms", elapsed)
+		}
+		return nil
+	}
+
+	config := func(rotation string) string {
+		return fmt.Sprintf(`
+			resource "utility_sleep" "settle" {
+				create_duration = "200ms"
+				destroy_duration = "10ms"
+				triggers = {
+					rotation = %q
+				}
+			}`, rotation)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { started = time.Now() },
+				Config:    config("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("utility_sleep.settle", "id"),
+					checkSlept,
+				),
+			},
+			{
+				// Changing triggers recreates the resource, waiting again.
+				PreConfig: func() { started = time.Now() },
+				Config:    config("2"),
+				Check:     checkSlept,
+			},
+		},
+	})
+}
+
+func TestSleepFor(t *testing.T) {
+	start := time.Now()
+	assert.NoError(t, sleepFor(context.Background(), types.StringValue("50ms")))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	assert.NoError(t, sleepFor(context.Background(), types.StringNull()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	assert.ErrorIs(t, sleepFor(ctx, types.StringValue("1h")), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
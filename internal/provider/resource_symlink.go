@@ -0,0 +1,174 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*symlinkResource)(nil)
+
+type symlinkResource struct{}
+
+func NewSymlinkResource() resource.Resource {
+	return &symlinkResource{}
+}
+
+func (r *symlinkResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_symlink"
+}
+
+func (r *symlinkResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to manage a symbolic link, such as a `current` link pointing at the directory of the deployed version. The link is replaced atomically when `target` changes, so it always points at either the old or the new target. A link changed outside of Terraform is pointed back at `target`. Destroying the resource removes the link but not its target.",
+		Attributes: map[string]schema.Attribute{
+			"target": schema.StringAttribute{
+				Description: "Path the link points at, stored as given. A relative path is resolved from the directory of `link_path`. The target does not have to exist.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"link_path": schema.StringAttribute{
+				Description: "Path of the link. Missing directories are created. An existing symbolic link is replaced, but any other file at this path is left alone and fails the creation.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The path of the link.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+type symlinkResourceModel struct {
+	Target   types.String `tfsdk:"target"`
+	LinkPath types.String `tfsdk:"link_path"`
+	ID       types.String `tfsdk:"id"`
+}
+
+func (r *symlinkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan symlinkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	linkPath := plan.LinkPath.ValueString()
+	if info, err := os.Lstat(linkPath); err == nil && info.Mode()&os.ModeSymlink == 0 {
+		resp.Diagnostics.AddError("Symlink Failed", fmt.Sprintf("%s already exists and is not a symbolic link.", linkPath))
+		return
+	}
+	if err := replaceSymlink(plan.Target.ValueString(), linkPath); err != nil {
+		resp.Diagnostics.AddError("Symlink Failed", err.Error())
+		return
+	}
+	plan.ID = plan.LinkPath
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *symlinkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state symlinkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := os.Lstat(state.LinkPath.ValueString())
+	if os.IsNotExist(err) || (err == nil && info.Mode()&os.ModeSymlink == 0) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	// Recording where the link points now plans an update that points it
+	// back at the configured target.
+	target, err := os.Readlink(state.LinkPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+	state.Target = types.StringValue(target)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *symlinkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan symlinkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := replaceSymlink(plan.Target.ValueString(), plan.LinkPath.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Symlink Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *symlinkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state symlinkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only remove the link itself, never a file that replaced it.
+	linkPath := state.LinkPath.ValueString()
+	if info, err := os.Lstat(linkPath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(linkPath); err != nil {
+			resp.Diagnostics.AddError("Delete Failed", err.Error())
+		}
+	}
+}
+
+// replaceSymlink points linkPath at target. The link is created under a
+// temporary name and renamed over linkPath, so that an existing link is
+// replaced atomically.
+func replaceSymlink(target, linkPath string) error {
+	dir := filepath.Dir(linkPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(dir, "."+filepath.Base(linkPath)+"."+strconv.Itoa(os.Getpid())+".tmp")
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Symlink(target, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, linkPath); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
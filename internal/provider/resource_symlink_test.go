@@ -0,0 +1,127 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymlinkResource(t *testing.T) {
+	dir := t.TempDir()
+	for _, release := range []string{"v1", "v2"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "releases", release), 0o755))
+	}
+	link := filepath.Join(dir, "current")
+
+	config := func(target string) string {
+		return fmt.Sprintf(`
+			resource "utility_symlink" "current" {
+				target = %q
+				link_path = %q
+			}`, target, link)
+	}
+	checkTarget := func(want string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			got, err := os.Readlink(link)
+			if err != nil {
+				return err
+			}
+			if got != want {
+				return fmt.Errorf("%s points at %s, want %s", link, got, want)
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("releases/v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_symlink.current", "id", link),
+					checkTarget("releases/v1"),
+				),
+			},
+			{
+				Config: config("releases/v2"),
+				Check:  checkTarget("releases/v2"),
+			},
+			{
+				// The link was pointed elsewhere outside of Terraform.
+				PreConfig: func() {
+					require.NoError(t, os.Remove(link))
+					require.NoError(t, os.Symlink("releases/v1", link))
+				},
+				Config: config("releases/v2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_symlink.current", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkTarget("releases/v2"),
+			},
+		},
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Lstat(link); !os.IsNotExist(err) {
+				return fmt.Errorf("%s was not removed: %v", link, err)
+			}
+			// The target is left alone.
+			_, err := os.Stat(filepath.Join(dir, "releases", "v2"))
+			return err
+		},
+	})
+}
+
+func TestSymlinkResource_ExistingFile(t *testing.T) {
+	link := filepath.Join(t.TempDir(), "current")
+	require.NoError(t, os.WriteFile(link, []byte("not a link"), 0o644))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_symlink" "existing_file" {
+						target = "releases/v1"
+						link_path = %q
+					}`, link),
+				ExpectError: regexp.MustCompile(`already exists and is not a symbolic link`),
+			},
+		},
+	})
+}
+
+func TestReplaceSymlink(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "nested", "current")
+
+	require.NoError(t, replaceSymlink("v1", link))
+	require.NoError(t, replaceSymlink("v2", link))
+	got, err := os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", got)
+
+	// A link to a directory is replaced, not followed.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "v3"), 0o755))
+	require.NoError(t, replaceSymlink(filepath.Join(dir, "v3"), link))
+	require.NoError(t, replaceSymlink("v4", link))
+	got, err = os.Readlink(link)
+	require.NoError(t, err)
+	assert.Equal(t, "v4", got)
+
+	entries, err := os.ReadDir(filepath.Dir(link))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
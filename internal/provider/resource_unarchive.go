@@ -0,0 +1,236 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type unarchiveResource struct{}
+
+func NewUnarchiveResource() resource.Resource {
+	return &unarchiveResource{}
+}
+
+func (r *unarchiveResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_unarchive"
+}
+
+func (r *unarchiveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource to extract a zip, tar or tar.gz archive into a directory. Entries with absolute paths or paths that would escape `output_dir` fail the extraction. The files are extracted again whenever the archive or one of the extracted files changes on disk, and are removed when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"archive_path": schema.StringAttribute{
+				Description: "Path of the archive to extract. The format is detected from the extension (.zip, .tar, .tar.gz or .tgz) or, for other names, from the content.",
+				Required:    true,
+			},
+			"output_dir": schema.StringAttribute{
+				Description: "Directory the archive is extracted into. It is created if it does not exist, and existing files are overwritten.",
+				Required:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The hexadecimal encoding of the aggregate SHA256 checksum of the extracted files.",
+				Computed:    true,
+			},
+			"files": schema.ListAttribute{
+				Description: "Paths of the extracted files, sorted. Directories, symbolic links and other special entries are not extracted.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "Aggregate SHA256 checksum over the paths, relative to `output_dir`, and contents of the extracted files, computed like the `sha256` of `utility_directory_checksum`.",
+				Computed:    true,
+			},
+			"archive_sha256": schema.StringAttribute{
+				Description: "SHA256 checksum of the archive.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *unarchiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan unarchiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.extract(); err != nil {
+		resp.Diagnostics.AddError("Extraction Failed", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *unarchiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state unarchiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	archive, err := hashFile(state.ArchivePath.ValueString())
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	files := state.fileList(ctx)
+	sums, err := hashExtracted(state.OutputDir.ValueString(), files)
+	if os.IsNotExist(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Read Failed", err.Error())
+		return
+	}
+
+	if archive.sha256Hex != state.ArchiveSha256.ValueString() || aggregateChecksum(sums) != state.Sha256.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *unarchiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan unarchiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state unarchiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := plan.extract(); err != nil {
+		resp.Diagnostics.AddError("Extraction Failed", err.Error())
+		return
+	}
+
+	// Remove the files of the previous extraction that the new one did
+	// not write again.
+	files := plan.fileList(ctx)
+	stale := slices.DeleteFunc(state.fileList(ctx), func(name string) bool {
+		return slices.Contains(files, name)
+	})
+	removeExtracted(state.OutputDir.ValueString(), stale)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *unarchiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state unarchiveResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removeExtracted(state.OutputDir.ValueString(), state.fileList(ctx))
+}
+
+type unarchiveResourceModel struct {
+	ArchivePath   types.String `tfsdk:"archive_path"`
+	OutputDir     types.String `tfsdk:"output_dir"`
+	ID            types.String `tfsdk:"id"`
+	Files         types.List   `tfsdk:"files"`
+	Sha256        types.String `tfsdk:"sha256"`
+	ArchiveSha256 types.String `tfsdk:"archive_sha256"`
+}
+
+func (m *unarchiveResourceModel) fileList(ctx context.Context) []string {
+	var files []string
+	m.Files.ElementsAs(ctx, &files, false)
+	return files
+}
+
+// extract extracts the archive and records the extracted files and the
+// checksums of the archive and of the extracted files.
+func (m *unarchiveResourceModel) extract() error {
+	archive, err := hashFile(m.ArchivePath.ValueString())
+	if err != nil {
+		return err
+	}
+
+	dir := m.OutputDir.ValueString()
+	files, err := extractArchive(m.ArchivePath.ValueString(), dir, extractOverwriteAlways)
+	if err != nil {
+		return err
+	}
+	slices.Sort(files)
+	// An archive may contain the same name twice; the last one wins.
+	files = slices.Compact(files)
+
+	sums, err := hashExtracted(dir, files)
+	if err != nil {
+		return err
+	}
+
+	values := make([]attr.Value, 0, len(files))
+	for _, file := range files {
+		values = append(values, types.StringValue(file))
+	}
+
+	checksum := aggregateChecksum(sums)
+	m.ID = types.StringValue(checksum)
+	m.Files = types.ListValueMust(types.StringType, values)
+	m.Sha256 = types.StringValue(checksum)
+	m.ArchiveSha256 = types.StringValue(archive.sha256Hex)
+
+	return nil
+}
+
+// hashExtracted returns the SHA256 checksums of files, keyed by their path
+// relative to dir with forward slashes.
+func hashExtracted(dir string, files []string) (map[string]string, error) {
+	sums := make(map[string]string, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return nil, err
+		}
+		checksums, err := hashFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sums[filepath.ToSlash(rel)] = checksums.sha256Hex
+	}
+	return sums, nil
+}
+
+// removeExtracted removes files and then those of their parent directories
+// below dir that are left empty.
+func removeExtracted(dir string, files []string) {
+	for _, file := range files {
+		os.Remove(file)
+	}
+	for _, file := range files {
+		for parent := filepath.Dir(file); parent != dir && parent != filepath.Dir(parent); parent = filepath.Dir(parent) {
+			if rel, err := filepath.Rel(dir, parent); err != nil || !filepath.IsLocal(rel) {
+				break
+			}
+			// Removing a directory that still has entries fails, which
+			// ends the walk.
+			if os.Remove(parent) != nil {
+				break
+			}
+		}
+	}
+}
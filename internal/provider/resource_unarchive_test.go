@@ -0,0 +1,105 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnarchiveResource(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "bundle.tar.gz")
+	writeTestTarGz(t, archive, map[string]string{
+		"conf/app.yaml": "replicas: 3",
+		"README.md":     "docs",
+	}, time.Now())
+	out := filepath.Join(dir, "out")
+
+	config := fmt.Sprintf(`
+		resource "utility_unarchive" "bundle" {
+			archive_path = %q
+			output_dir = %q
+		}`, archive, out)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_unarchive.bundle", "files.#", "2"),
+					resource.TestCheckResourceAttr("utility_unarchive.bundle", "files.0", filepath.Join(out, "README.md")),
+					resource.TestCheckResourceAttr("utility_unarchive.bundle", "files.1", filepath.Join(out, "conf", "app.yaml")),
+					resource.TestCheckResourceAttrSet("utility_unarchive.bundle", "sha256"),
+					resource.TestCheckResourceAttrSet("utility_unarchive.bundle", "archive_sha256"),
+				),
+			},
+			{
+				// A modified file is extracted again.
+				PreConfig: func() {
+					require.NoError(t, os.WriteFile(filepath.Join(out, "conf", "app.yaml"), []byte("local"), 0o644))
+				},
+				Config: config,
+				Check: func(*terraform.State) error {
+					got, err := os.ReadFile(filepath.Join(out, "conf", "app.yaml"))
+					if err != nil {
+						return err
+					}
+					if string(got) != "replicas: 3" {
+						return fmt.Errorf("conf/app.yaml = %q, want the archived content", got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestUnarchiveResource_PathTraversal(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "evil.tar.gz")
+	writeTestTarGz(t, archive, map[string]string{"../../escape.txt": "x"}, time.Now())
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_unarchive" "evil" {
+						archive_path = %q
+						output_dir = %q
+					}`, archive, filepath.Join(dir, "a", "out")),
+				ExpectError: regexp.MustCompile(`outside of the extraction directory`),
+			},
+		},
+	})
+}
+
+func TestRemoveExtracted(t *testing.T) {
+	dir := t.TempDir()
+	writeTestTree(t, dir, map[string]string{
+		"a/b/one.txt": "1",
+		"a/two.txt":   "2",
+		"keep.txt":    "k",
+	})
+
+	removeExtracted(dir, []string{filepath.Join(dir, "a", "b", "one.txt")})
+	assert.NoDirExists(t, filepath.Join(dir, "a", "b"))
+	assert.FileExists(t, filepath.Join(dir, "a", "two.txt"))
+
+	removeExtracted(dir, []string{filepath.Join(dir, "a", "two.txt")})
+	assert.NoDirExists(t, filepath.Join(dir, "a"))
+	assert.FileExists(t, filepath.Join(dir, "keep.txt"))
+	assert.DirExists(t, dir)
+}
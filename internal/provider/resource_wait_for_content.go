@@ -0,0 +1,224 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithConfigure = (*waitForContentResource)(nil)
+
+type waitForContentResource struct {
+	providerData *providerData
+}
+
+func NewWaitForContentResource() resource.Resource {
+	return &waitForContentResource{}
+}
+
+func (r *waitForContentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *waitForContentResource) hostLimiter() *hostLimiter {
+	if r.providerData == nil {
+		return nil
+	}
+	return r.providerData.hostLimiter
+}
+
+func (r *waitForContentResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_wait_for_content"
+}
+
+func (r *waitForContentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that polls an HTTP(S) endpoint with GET until it answers 200 OK with a body matching a regular expression, such as a version marker showing that a deployment has rolled out, and exposes the matching body. The endpoint is polled again whenever an argument changes.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "The full HTTP or HTTPS URL to poll.",
+				Required:    true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in the request. References to environment variables written `$${env:NAME}` or `$${env:NAME:-default}` are expanded before the first attempt, and an unset variable without a default fails right away.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"match_regex": schema.StringAttribute{
+				Description: "Regular expression, in the syntax of Go's `regexp` package, the response body must match. A string without special characters such as `.`, `*` or `(` matches itself.",
+				Required:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"interval": schema.StringAttribute{
+				Description: "Time to wait between attempts, as a duration such as \"5s\" (default: 5s).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5s"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for a matching body, as a duration such as \"5m\" (default: 5m).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5m"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"body": schema.StringAttribute{
+				Description: "Body of the response that matched.",
+				Computed:    true,
+			},
+			"match": schema.StringAttribute{
+				Description: "The part of `body` that `match_regex` matched, such as the version marker.",
+				Computed:    true,
+			},
+			"attempts": schema.Int64Attribute{
+				Description: "Number of requests made before the body matched.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The polled URL.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *waitForContentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan waitForContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.wait(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForContentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state waitForContentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *waitForContentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan waitForContentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.wait(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForContentResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type waitForContentResourceModel struct {
+	URL        types.String `tfsdk:"url"`
+	Headers    types.Map    `tfsdk:"headers"`
+	MatchRegex types.String `tfsdk:"match_regex"`
+	Interval   types.String `tfsdk:"interval"`
+	Timeout    types.String `tfsdk:"timeout"`
+	Body       types.String `tfsdk:"body"`
+	Match      types.String `tfsdk:"match"`
+	Attempts   types.Int64  `tfsdk:"attempts"`
+	ID         types.String `tfsdk:"id"`
+}
+
+// wait polls until the body matches and records the body, the match and
+// the number of attempts in plan.
+func (r *waitForContentResource) wait(ctx context.Context, plan *waitForContentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	interval, _ := time.ParseDuration(plan.Interval.ValueString())
+	timeout, _ := time.ParseDuration(plan.Timeout.ValueString())
+
+	bodyRegex, err := regexp.Compile(plan.MatchRegex.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Regular Expression", err.Error())
+		return diags
+	}
+
+	headers, err := expandHeaders(stringMapValue(plan.Headers))
+	if err != nil {
+		diags.AddError("Invalid Headers", err.Error())
+		return diags
+	}
+
+	check := httpCheck{
+		method:         http.MethodGet,
+		url:            plan.URL.ValueString(),
+		headers:        headers,
+		expectedStatus: http.StatusOK,
+		bodyRegex:      bodyRegex,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	body, attempts, err := check.poll(ctx, r.hostLimiter(), interval)
+	if errors.Is(err, errInvalidRequest) {
+		diags.AddError("Invalid Request", err.Error())
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Wait For Content Timed Out",
+			fmt.Sprintf("%s did not return a body matching %q after %d attempts. Last response: %s", check.url, bodyRegex, attempts, err),
+		)
+		return diags
+	}
+
+	plan.Body = types.StringValue(body)
+	plan.Match = types.StringValue(bodyRegex.FindString(body))
+	plan.Attempts = types.Int64Value(attempts)
+	plan.ID = plan.URL
+
+	return diags
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDeploymentServer returns a server that reports version 1.0.0 until
+// rolledOutAt and version 1.1.0 afterwards.
+func newDeploymentServer(rolledOutAt time.Time) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := "1.0.0"
+		if !time.Now().Before(rolledOutAt) {
+			version = "1.1.0"
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"ok","version":%q}`, version)
+	}))
+}
+
+func TestWaitForContentResource(t *testing.T) {
+	rolledOutAt := time.Now().Add(300 * time.Millisecond)
+	ts := newDeploymentServer(rolledOutAt)
+	defer ts.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_content" "rollout" {
+						url         = %q
+						match_regex = "\"version\":\"1\\.1\\.[0-9]+\""
+						interval    = "50ms"
+						timeout     = "10s"
+					}`, ts.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_wait_for_content.rollout", "id", ts.URL),
+					resource.TestCheckResourceAttr("utility_wait_for_content.rollout", "body", `{"status":"ok","version":"1.1.0"}`),
+					resource.TestCheckResourceAttr("utility_wait_for_content.rollout", "match", `"version":"1.1.0"`),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitForContentResource_Timeout(t *testing.T) {
+	ts := newDeploymentServer(time.Now().Add(time.Hour))
+	defer ts.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_content" "rollout" {
+						url         = %q
+						match_regex = "1\\.1\\.0"
+						interval    = "10ms"
+						timeout     = "200ms"
+					}`, ts.URL),
+				ExpectError: regexp.MustCompile(`did not return a body matching`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_content" "rollout" {
+						url         = %q
+						match_regex = "version\":\"(1"
+					}`, ts.URL),
+				ExpectError: regexp.MustCompile(`value must be a regular expression`),
+			},
+		},
+	})
+}
+
+func testWaitForContentModel(url, matchRegex, interval, timeout string) waitForContentResourceModel {
+	return waitForContentResourceModel{
+		URL:        types.StringValue(url),
+		Headers:    types.MapNull(types.StringType),
+		MatchRegex: types.StringValue(matchRegex),
+		Interval:   types.StringValue(interval),
+		Timeout:    types.StringValue(timeout),
+	}
+}
+
+func TestWaitForContentResource_Wait(t *testing.T) {
+	rolledOutAt := time.Now().Add(200 * time.Millisecond)
+	ts := newDeploymentServer(rolledOutAt)
+	defer ts.Close()
+
+	r := &waitForContentResource{}
+	m := testWaitForContentModel(ts.URL, `"version":"(1\.1\.\d+)"`, "20ms", "10s")
+	diags := r.wait(context.Background(), &m)
+	require.False(t, diags.HasError(), diags)
+
+	assert.False(t, time.Now().Before(rolledOutAt), "the wait ends once the body changed")
+	assert.Equal(t, `{"status":"ok","version":"1.1.0"}`, m.Body.ValueString())
+	assert.Equal(t, `"version":"1.1.0"`, m.Match.ValueString())
+	assert.Greater(t, m.Attempts.ValueInt64(), int64(1))
+	assert.Equal(t, ts.URL, m.ID.ValueString())
+}
+
+func TestWaitForContentResource_WaitTimeout(t *testing.T) {
+	ts := newDeploymentServer(time.Now().Add(time.Hour))
+	defer ts.Close()
+
+	r := &waitForContentResource{}
+	m := testWaitForContentModel(ts.URL, `1\.1\.0`, "10ms", "100ms")
+	diags := r.wait(context.Background(), &m)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "Wait For Content Timed Out", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), `200 OK: {"status":"ok","version":"1.0.0"} (body does not match "1\\.1\\.0")`)
+	assert.True(t, m.Body.IsNull())
+}
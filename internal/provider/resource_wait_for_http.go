@@ -0,0 +1,369 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithConfigure = (*waitForHTTPResource)(nil)
+
+type waitForHTTPResource struct {
+	providerData *providerData
+}
+
+func NewWaitForHTTPResource() resource.Resource {
+	return &waitForHTTPResource{}
+}
+
+func (r *waitForHTTPResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *waitForHTTPResource) hostLimiter() *hostLimiter {
+	if r.providerData == nil {
+		return nil
+	}
+	return r.providerData.hostLimiter
+}
+
+func (r *waitForHTTPResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_wait_for_http"
+}
+
+func (r *waitForHTTPResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that polls an HTTP(S) endpoint until every configured success predicate holds. Use it to gate other resources on a service becoming ready.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "The full HTTP or HTTPS URL to poll.",
+				Required:    true,
+			},
+			"method": schema.StringAttribute{
+				Description: "HTTP method to use for the request (default: GET). Only 'GET', 'HEAD' and 'POST' are allowed.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodHead, http.MethodPost),
+				},
+				Default: stringdefault.StaticString(http.MethodGet),
+			},
+			"headers": schema.MapAttribute{
+				Description: "Map of custom HTTP headers to include in the request. References to environment variables written `$${env:NAME}` or `$${env:NAME:-default}` are expanded before the first attempt, and an unset variable without a default fails right away.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Sensitive:   true,
+			},
+			"expected_status": schema.Int64Attribute{
+				Description: "HTTP status code the response must have (default: 200).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(http.StatusOK),
+			},
+			"body_contains": schema.StringAttribute{
+				Description: "Substring the response body must contain.",
+				Optional:    true,
+			},
+			"body_matches_regex": schema.StringAttribute{
+				Description: "Regular expression the response body must match.",
+				Optional:    true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"header_equals": schema.MapAttribute{
+				Description: "Map of response header names to the exact values they must have.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"interval": schema.StringAttribute{
+				Description: "Time to wait between attempts, as a duration such as \"5s\" (default: 5s).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5s"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the predicates to hold, as a duration such as \"5m\" (default: 5m).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5m"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"attempts": schema.Int64Attribute{
+				Description: "Number of requests made before the predicates held.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The polled URL.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *waitForHTTPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan waitForHTTPResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.wait(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForHTTPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state waitForHTTPResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *waitForHTTPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan waitForHTTPResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.wait(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForHTTPResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type waitForHTTPResourceModel struct {
+	URL              types.String `tfsdk:"url"`
+	Method           types.String `tfsdk:"method"`
+	Headers          types.Map    `tfsdk:"headers"`
+	ExpectedStatus   types.Int64  `tfsdk:"expected_status"`
+	BodyContains     types.String `tfsdk:"body_contains"`
+	BodyMatchesRegex types.String `tfsdk:"body_matches_regex"`
+	HeaderEquals     types.Map    `tfsdk:"header_equals"`
+	Interval         types.String `tfsdk:"interval"`
+	Timeout          types.String `tfsdk:"timeout"`
+	Attempts         types.Int64  `tfsdk:"attempts"`
+	ID               types.String `tfsdk:"id"`
+}
+
+// wait polls until the predicates in plan hold and records the number of
+// attempts in plan.
+func (r *waitForHTTPResource) wait(ctx context.Context, plan *waitForHTTPResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	interval, _ := time.ParseDuration(plan.Interval.ValueString())
+	timeout, _ := time.ParseDuration(plan.Timeout.ValueString())
+
+	var bodyRegex *regexp.Regexp
+	if plan.BodyMatchesRegex.ValueString() != "" {
+		var err error
+		bodyRegex, err = regexp.Compile(plan.BodyMatchesRegex.ValueString())
+		if err != nil {
+			diags.AddError("Invalid Regular Expression", err.Error())
+			return diags
+		}
+	}
+
+	// Expand the headers once, so that an unset environment variable fails
+	// right away instead of on every attempt until the timeout.
+	headers, err := expandHeaders(stringMapValue(plan.Headers))
+	if err != nil {
+		diags.AddError("Invalid Headers", err.Error())
+		return diags
+	}
+
+	check := httpCheck{
+		method:         strings.ToUpper(plan.Method.ValueString()),
+		url:            plan.URL.ValueString(),
+		headers:        headers,
+		expectedStatus: int(plan.ExpectedStatus.ValueInt64()),
+		bodyContains:   plan.BodyContains.ValueString(),
+		bodyRegex:      bodyRegex,
+		headerEquals:   stringMapValue(plan.HeaderEquals),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, attempts, err := check.poll(ctx, r.hostLimiter(), interval)
+	if errors.Is(err, errInvalidRequest) {
+		diags.AddError("Invalid Request", err.Error())
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Wait For HTTP Timed Out",
+			fmt.Sprintf("%s did not become ready after %d attempts. Last response: %s", check.url, attempts, err),
+		)
+		return diags
+	}
+
+	plan.Attempts = types.Int64Value(attempts)
+	plan.ID = plan.URL
+
+	return diags
+}
+
+// httpCheck is a single request together with the predicates its response
+// must satisfy.
+type httpCheck struct {
+	method         string
+	url            string
+	headers        map[string]string
+	expectedStatus int
+	bodyContains   string
+	bodyRegex      *regexp.Regexp
+	headerEquals   map[string]string
+}
+
+// maxSummaryBodyLength bounds how much of the body is quoted in a response
+// summary.
+const maxSummaryBodyLength = 256
+
+// maxCheckBodyBytes bounds how much of a response body is read on each
+// attempt. The body predicates only see this much of a larger body.
+const maxCheckBodyBytes = 1 << 20
+
+// httpCheckClient sends the requests of an httpCheck. Its timeout keeps a
+// single hanging attempt from using up the whole wait.
+var httpCheckClient = &http.Client{Timeout: 30 * time.Second}
+
+// errInvalidRequest marks failures that no further attempt can fix, such as
+// a malformed URL, so that poll stops right away.
+var errInvalidRequest = errors.New("invalid request")
+
+// poll runs c every interval until all its predicates hold or ctx is done,
+// and returns the body of the last response and the number of attempts. If
+// ctx is done first, the error summarizes the last complete response. An
+// error wrapping errInvalidRequest is returned after the first attempt.
+func (c httpCheck) poll(ctx context.Context, limiter *hostLimiter, interval time.Duration) (string, int64, error) {
+	var attempts int64
+	var lastErr error
+	for {
+		attempts++
+
+		body, err := c.run(ctx, limiter)
+		if err == nil {
+			return body, attempts, nil
+		}
+		if errors.Is(err, errInvalidRequest) {
+			return "", attempts, err
+		}
+		// Keep the previous response when the attempt was cut short by the
+		// timeout, as it is more useful than "context deadline exceeded".
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", attempts, lastErr
+		case <-time.After(interval):
+		}
+	}
+}
+
+// run performs the request and returns the response body, or an error
+// summarizing the response if any predicate did not hold.
+func (c httpCheck) run(ctx context.Context, limiter *hostLimiter) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errInvalidRequest, err)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return "", fmt.Errorf("%w: %s is not an HTTP or HTTPS URL", errInvalidRequest, c.url)
+	}
+
+	release, err := limiter.acquire(ctx, req.URL.Host)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := httpCheckClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(io.LimitReader(resp.Body, maxCheckBodyBytes))
+	if err != nil {
+		return "", err
+	}
+	body := string(bs)
+
+	summary := resp.Status
+	if len(body) > maxSummaryBodyLength {
+		summary += ": " + body[:maxSummaryBodyLength] + "..."
+	} else if body != "" {
+		summary += ": " + body
+	}
+
+	if resp.StatusCode != c.expectedStatus {
+		return "", errors.New(summary)
+	}
+	if c.bodyContains != "" && !strings.Contains(body, c.bodyContains) {
+		return "", fmt.Errorf("%s (body does not contain %q)", summary, c.bodyContains)
+	}
+	if c.bodyRegex != nil && !c.bodyRegex.MatchString(body) {
+		return "", fmt.Errorf("%s (body does not match %q)", summary, c.bodyRegex)
+	}
+	for k, v := range c.headerEquals {
+		if got := resp.Header.Get(k); got != v {
+			return "", fmt.Errorf("%s (header %s is %q, want %q)", summary, k, got, v)
+		}
+	}
+
+	return body, nil
+}
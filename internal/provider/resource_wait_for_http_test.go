@@ -0,0 +1,125 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForHTTPResource(t *testing.T) {
+	var calls atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("X-Status", "ready")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok","version":"1.2.3"}`))
+	}))
+	defer ts.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_http" "ready" {
+						url = "%s"
+						interval = "10ms"
+						timeout = "10s"
+						body_contains = "\"status\":\"ok\""
+						body_matches_regex = "version\":\"1\\.[0-9]+"
+						header_equals = {
+							X-Status = "ready"
+						}
+					}`, ts.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_wait_for_http.ready", "id", ts.URL),
+					resource.TestCheckResourceAttr("utility_wait_for_http.ready", "attempts", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitForHTTPResource_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("warming up"))
+	}))
+	defer ts.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_http" "never_ready" {
+						url = "%s"
+						interval = "10ms"
+						timeout = "200ms"
+					}`, ts.URL),
+				ExpectError: regexp.MustCompile(`503 Service Unavailable: warming up`),
+			},
+		},
+	})
+}
+
+func TestWaitForHTTPResource_InvalidRegex(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "utility_wait_for_http" "invalid_regex" {
+						url = "http://127.0.0.1:1"
+						body_matches_regex = "version\":\"(1"
+					}`,
+				ExpectError: regexp.MustCompile(`value must be a regular expression`),
+			},
+		},
+	})
+}
+
+func TestHTTPCheck_Poll(t *testing.T) {
+	// An endless body is only read up to maxCheckBodyBytes.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chunk := []byte(strings.Repeat("x", 32<<10))
+		for {
+			if _, err := w.Write(chunk); err != nil {
+				return
+			}
+		}
+	}))
+	defer ts.Close()
+
+	check := httpCheck{method: http.MethodGet, url: ts.URL, expectedStatus: http.StatusOK}
+	body, attempts, err := check.poll(context.Background(), nil, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), attempts)
+	assert.Len(t, body, maxCheckBodyBytes)
+
+	// A request that cannot be built is not retried until the timeout.
+	for _, rawURL := range []string{"http://[::1", "localhost:8080/healthz"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		check := httpCheck{method: http.MethodGet, url: rawURL, expectedStatus: http.StatusOK}
+		_, attempts, err := check.poll(ctx, nil, 10*time.Millisecond)
+		cancel()
+		require.ErrorIs(t, err, errInvalidRequest, rawURL)
+		assert.Equal(t, int64(1), attempts, rawURL)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = (*waitForPortResource)(nil)
+
+type waitForPortResource struct{}
+
+func NewWaitForPortResource() resource.Resource {
+	return &waitForPortResource{}
+}
+
+func (r *waitForPortResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_wait_for_port"
+}
+
+func (r *waitForPortResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that dials a TCP address until a connection succeeds. Use it to gate other resources on a service that does not speak HTTP, such as a database, being up.",
+		Attributes: map[string]schema.Attribute{
+			"address": schema.StringAttribute{
+				Description: "The address to connect to, as `host:port`.",
+				Required:    true,
+				Validators: []validator.String{
+					hostPortValidator{},
+				},
+			},
+			"interval": schema.StringAttribute{
+				Description: "Time to wait between attempts, as a duration such as \"5s\" (default: 5s).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5s"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for a connection, as a duration such as \"5m\" (default: 5m).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5m"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"attempts": schema.Int64Attribute{
+				Description: "Number of connection attempts made before one succeeded.",
+				Computed:    true,
+			},
+			"connected_at": schema.StringAttribute{
+				Description: "Time the connection succeeded, in RFC 3339 format.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The dialed address.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *waitForPortResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan waitForPortResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForPortResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state waitForPortResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *waitForPortResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan waitForPortResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.wait(ctx)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForPortResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type waitForPortResourceModel struct {
+	Address     types.String `tfsdk:"address"`
+	Interval    types.String `tfsdk:"interval"`
+	Timeout     types.String `tfsdk:"timeout"`
+	Attempts    types.Int64  `tfsdk:"attempts"`
+	ConnectedAt types.String `tfsdk:"connected_at"`
+	ID          types.String `tfsdk:"id"`
+}
+
+// wait dials until a connection succeeds and records the number of attempts
+// and the time of the connection in m.
+func (m *waitForPortResourceModel) wait(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	interval, _ := time.ParseDuration(m.Interval.ValueString())
+	timeout, _ := time.ParseDuration(m.Timeout.ValueString())
+	address := m.Address.ValueString()
+
+	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var dialer net.Dialer
+	var attempts int64
+	var lastErr error
+	for {
+		attempts++
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		// Keep the previous error when the attempt was cut short by the
+		// timeout, as it is more useful than "i/o timeout".
+		if time.Now().Before(deadline) || lastErr == nil {
+			lastErr = err
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			diags.AddError(
+				"Wait For Port Timed Out",
+				fmt.Sprintf("%s did not accept a connection after %d attempts. Last error: %s", address, attempts, lastErr),
+			)
+			return diags
+		}
+	}
+
+	m.Attempts = types.Int64Value(attempts)
+	m.ConnectedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	m.ID = m.Address
+
+	return diags
+}
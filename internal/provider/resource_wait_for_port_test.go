@@ -0,0 +1,77 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForPortResource(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_port" "db" {
+						address = "%s"
+						interval = "10ms"
+						timeout = "10s"
+					}`, ln.Addr()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_wait_for_port.db", "id", ln.Addr().String()),
+					resource.TestCheckResourceAttr("utility_wait_for_port.db", "attempts", "1"),
+					resource.TestCheckResourceAttrSet("utility_wait_for_port.db", "connected_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitForPortResource_Timeout(t *testing.T) {
+	// Reserve a port, then close it so nothing is listening on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "utility_wait_for_port" "closed" {
+						address = "%s"
+						interval = "10ms"
+						timeout = "200ms"
+					}`, address),
+				ExpectError: regexp.MustCompile(`connection refused`),
+			},
+		},
+	})
+}
+
+func TestWaitForPortResource_InvalidAddress(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "utility_wait_for_port" "invalid" {
+						address = "localhost"
+					}`,
+				ExpectError: regexp.MustCompile(`host:port`),
+			},
+		},
+	})
+}
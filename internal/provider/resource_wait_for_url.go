@@ -0,0 +1,199 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithConfigure = (*waitForURLResource)(nil)
+
+type waitForURLResource struct {
+	providerData *providerData
+}
+
+func NewWaitForURLResource() resource.Resource {
+	return &waitForURLResource{}
+}
+
+func (r *waitForURLResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *waitForURLResource) hostLimiter() *hostLimiter {
+	if r.providerData == nil {
+		return nil
+	}
+	return r.providerData.hostLimiter
+}
+
+func (r *waitForURLResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "utility_wait_for_url"
+}
+
+func (r *waitForURLResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource that polls a URL with GET until it answers with the expected status, such as a service that was just provisioned. It only waits when it is created: refreshing or updating it sends no requests, and changing `url` replaces it to wait for the new URL. Use `utility_wait_for_http` to check the body or headers of the response as well.",
+		Attributes: map[string]schema.Attribute{
+			"url": schema.StringAttribute{
+				Description: "The full HTTP or HTTPS URL to poll.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expected_status": schema.Int64Attribute{
+				Description: "HTTP status code the response must have (default: 200).",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(http.StatusOK),
+			},
+			"interval": schema.StringAttribute{
+				Description: "Time to wait between attempts, as a duration such as \"5s\" (default: 5s).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5s"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the expected status, as a duration such as \"5m\" (default: 5m).",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("5m"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"attempts": schema.Int64Attribute{
+				Description: "Number of requests made before the expected status was seen.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description: "The polled URL.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *waitForURLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan waitForURLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.wait(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForURLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state waitForURLResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update records the new interval, timeout or expected status without
+// polling, as the URL was already seen healthy.
+func (r *waitForURLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan waitForURLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *waitForURLResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+type waitForURLResourceModel struct {
+	URL            types.String `tfsdk:"url"`
+	ExpectedStatus types.Int64  `tfsdk:"expected_status"`
+	Interval       types.String `tfsdk:"interval"`
+	Timeout        types.String `tfsdk:"timeout"`
+	Attempts       types.Int64  `tfsdk:"attempts"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// wait polls until the URL of plan answers with the expected status and
+// records the number of attempts in plan.
+func (r *waitForURLResource) wait(ctx context.Context, plan *waitForURLResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	interval, _ := time.ParseDuration(plan.Interval.ValueString())
+	timeout, _ := time.ParseDuration(plan.Timeout.ValueString())
+
+	check := httpCheck{
+		method:         http.MethodGet,
+		url:            plan.URL.ValueString(),
+		expectedStatus: int(plan.ExpectedStatus.ValueInt64()),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	_, attempts, err := check.poll(ctx, r.hostLimiter(), interval)
+	if errors.Is(err, errInvalidRequest) {
+		diags.AddError("Invalid Request", err.Error())
+		return diags
+	}
+	if err != nil {
+		diags.AddError(
+			"Wait For URL Timed Out",
+			fmt.Sprintf("%s did not answer with status %d after %d attempts. Last response: %s", check.url, check.expectedStatus, attempts, err),
+		)
+		return diags
+	}
+
+	plan.Attempts = types.Int64Value(attempts)
+	plan.ID = plan.URL
+
+	return diags
+}
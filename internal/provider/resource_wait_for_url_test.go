@@ -0,0 +1,99 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestWaitForURLResource(t *testing.T) {
+	// The service starts answering once it has warmed up for a while,
+	// however many requests arrived in the meantime.
+	readyAt := time.Now().Add(300 * time.Millisecond)
+	var unavailable, ok atomic.Int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" || time.Now().Before(readyAt) {
+			unavailable.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		ok.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	config := func(path, timeout string) string {
+		return fmt.Sprintf(`
+			resource "utility_wait_for_url" "service" {
+				url      = "%s%s"
+				interval = "50ms"
+				timeout  = %q
+			}`, ts.URL, path, timeout)
+	}
+
+	// noRequests checks that no request was sent since the last check.
+	var seen atomic.Int64
+	noRequests := func(*terraform.State) error {
+		if n := unavailable.Load() + ok.Load(); n != seen.Load() {
+			return fmt.Errorf("%d requests were sent, want none", n-seen.Load())
+		}
+		return nil
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: protoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("/healthz", "10s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_wait_for_url.service", "id", ts.URL+"/healthz"),
+					resource.TestCheckResourceAttr("utility_wait_for_url.service", "expected_status", "200"),
+					func(s *terraform.State) error {
+						// The resource polled through the 503 responses and
+						// stopped at the first 200.
+						if unavailable.Load() == 0 || ok.Load() != 1 {
+							return fmt.Errorf("got %d 503 and %d 200 responses, want some 503 and one 200", unavailable.Load(), ok.Load())
+						}
+						seen.Store(unavailable.Load() + ok.Load())
+						return resource.TestCheckResourceAttr("utility_wait_for_url.service", "attempts", strconv.FormatInt(unavailable.Load()+1, 10))(s)
+					},
+				),
+			},
+			{
+				// Updates and refreshes send no requests.
+				Config: config("/healthz", "1m"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_wait_for_url.service", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("utility_wait_for_url.service", "timeout", "1m"),
+					noRequests,
+				),
+			},
+			{
+				// A new URL replaces the resource, which waits for it.
+				Config: config("/down", "200ms"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("utility_wait_for_url.service", plancheck.ResourceActionReplace),
+					},
+				},
+				ExpectError: regexp.MustCompile(`did not answer with status 200 after \d+ attempts`),
+			},
+		},
+	})
+}
@@ -0,0 +1,331 @@
+// Copyright (c) Frontiers.gg
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var (
+	_ validator.String = durationValidator{}
+	_ validator.String = localAddressValidator{}
+	_ validator.String = hostPortValidator{}
+	_ validator.String = fileModeValidator{}
+	_ validator.String = globValidator{}
+	_ validator.String = proxyURLValidator{}
+	_ validator.String = regexValidator{}
+	_ validator.String = magicBytesValidator{}
+)
+
+// durationValidator validates that a string attribute is a positive Go
+// duration such as "30s" or "5m".
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return `value must be a positive duration such as "30s" or "5m"`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// localAddressValidator validates that a string attribute is an IP address
+// assigned to one of the local network interfaces.
+type localAddressValidator struct{}
+
+func (v localAddressValidator) Description(_ context.Context) string {
+	return "value must be an IP address of a local network interface"
+}
+
+func (v localAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v localAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	ip := net.ParseIP(value)
+	if ip == nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address",
+			fmt.Sprintf("Attribute %s %s, got: %q, which is not an IP address.", req.Path, v.Description(ctx), value),
+		)
+		return
+	}
+
+	if err := checkLocalAddress(ip); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Source Address",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// hostPortValidator validates that a string attribute is a network address
+// of the form "host:port".
+type hostPortValidator struct{}
+
+func (v hostPortValidator) Description(_ context.Context) string {
+	return `value must be an address of the form "host:port"`
+}
+
+func (v hostPortValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostPortValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	host, port, err := net.SplitHostPort(req.ConfigValue.ValueString())
+	if err != nil || host == "" || port == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Address",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// fileModeValidator validates that a string attribute is an octal
+// permission mode such as "0644".
+type fileModeValidator struct{}
+
+func (v fileModeValidator) Description(_ context.Context) string {
+	return `value must be an octal permission mode such as "0644"`
+}
+
+func (v fileModeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fileModeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseFileMode(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid File Mode",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// globValidator validates that a string attribute is a glob pattern in the
+// syntax of path.Match.
+type globValidator struct{}
+
+func (v globValidator) Description(_ context.Context) string {
+	return "value must be a glob pattern such as \"*.tmp\" or \"cache/*\""
+}
+
+func (v globValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v globValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := path.Match(req.ConfigValue.ValueString(), ""); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Glob Pattern",
+			fmt.Sprintf("Attribute %s %s, got: %q: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// regexValidator validates that a string attribute is a regular expression
+// in the syntax of Go's regexp package.
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a regular expression in the syntax of Go's regexp package"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("Attribute %s %s, got: %q: %s", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// magicBytesValidator validates that a string attribute is a hex-encoded
+// byte prefix or the name of a file type in magicBytes.
+type magicBytesValidator struct{}
+
+func (v magicBytesValidator) Description(_ context.Context) string {
+	return "value must be hex-encoded bytes such as \"1f8b\" or a file type such as \"gzip\""
+}
+
+func (v magicBytesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v magicBytesValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseMagicBytes(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Magic Bytes",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// base64Validator validates that a string attribute is standard base64
+// encoded.
+type base64Validator struct{}
+
+func (v base64Validator) Description(_ context.Context) string {
+	return "value must be standard base64 encoded"
+}
+
+func (v base64Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v base64Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Base64",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// proxyURLValidator validates that a string attribute is an HTTP, HTTPS or
+// SOCKS5 proxy URL.
+type proxyURLValidator struct{}
+
+func (v proxyURLValidator) Description(_ context.Context) string {
+	return `value must be a proxy URL with the scheme "http", "https" or "socks5", such as "http://proxy.internal:3128"`
+}
+
+func (v proxyURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v proxyURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseProxyURL(req.ConfigValue.ValueString()); err != nil {
+		// The URL may hold credentials, so only the error is reported.
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Proxy URL",
+			fmt.Sprintf("Attribute %s %s: %s", req.Path, v.Description(ctx), err),
+		)
+	}
+}
+
+// parseProxyURL parses an HTTP, HTTPS or SOCKS5 proxy URL. Its errors do not
+// include the URL, which may hold credentials.
+func parseProxyURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, err
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	return u, nil
+}
+
+// parseFileMode parses an octal permission mode such as "0644". Only the
+// permission bits are accepted.
+func parseFileMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if mode > uint64(os.ModePerm) {
+		return 0, fmt.Errorf("%s has bits outside of the permission bits", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// checkLocalAddress returns an error unless ip is assigned to a local
+// network interface.
+func checkLocalAddress(ip net.IP) error {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("listing local addresses: %w", err)
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not assigned to any local network interface", ip)
+}